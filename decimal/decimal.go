@@ -0,0 +1,335 @@
+// Package decimal provides the fixed-point Decimal used for the prices,
+// quantities, values and fees of the Bybit REST and WebSocket responses.
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DivisionPrecision is the number of decimal places kept by Div
+var DivisionPrecision int32 = 16
+
+// MaxExponent bounds the exponent accepted by NewFromString, so that untrusted
+// input such as "1e2000000000" cannot blow up the arithmetic and formatting
+const MaxExponent = 1000
+
+// Zero is the zero Decimal, the zero value of Decimal is also 0
+var Zero = Decimal{}
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// Decimal is an arbitrary-precision fixed-point decimal, coef * 10^exp.
+// Decimals are immutable, every operation returns a new value.
+type Decimal struct {
+	coef *big.Int // nil means 0
+	exp  int32
+}
+
+// New returns value * 10^exp, e.g. New(35005, -1) is 3500.5
+func New(value int64, exp int32) Decimal {
+	return Decimal{
+		coef: big.NewInt(value),
+		exp:  exp,
+	}
+}
+
+// NewFromInt returns value as a Decimal
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromFloat returns the shortest decimal representation of value,
+// it panics on NaN and infinities
+func NewFromFloat(value float64) Decimal {
+	d, err := NewFromString(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		panic(fmt.Sprintf("decimal: cannot convert %v", value))
+	}
+	return d
+}
+
+// NewFromString parses "-123.45", "1e-8" or "2.5E+3", the exponent must be
+// within ±MaxExponent
+func NewFromString(s string) (Decimal, error) {
+	orig := s
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("decimal: cannot parse %q", orig)
+		}
+		exp = e
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		exp -= int64(len(s) - i - 1)
+		s = s[:i] + s[i+1:]
+	}
+	digits := strings.TrimLeft(s, "+-")
+	if digits == "" || len(s)-len(digits) > 1 || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q", orig)
+	}
+	if exp < -MaxExponent || exp > MaxExponent {
+		return Decimal{}, fmt.Errorf("decimal: exponent out of range %q", orig)
+	}
+	coef, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q", orig)
+	}
+	return Decimal{coef: coef, exp: int32(exp)}, nil
+}
+
+// RequireFromString is NewFromString panicking on error, for constants
+func RequireFromString(s string) Decimal {
+	d, err := NewFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) value() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d at exp, exp must be <= d.exp
+func (d Decimal) rescale(exp int32) *big.Int {
+	c := new(big.Int).Set(d.value())
+	if exp < d.exp {
+		c.Mul(c, pow10(int64(d.exp)-int64(exp)))
+	}
+	return c
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
+
+func align(d, d2 Decimal) (*big.Int, *big.Int, int32) {
+	exp := d.exp
+	if d2.exp < exp {
+		exp = d2.exp
+	}
+	return d.rescale(exp), d2.rescale(exp), exp
+}
+
+// Add returns d + d2
+func (d Decimal) Add(d2 Decimal) Decimal {
+	a, b, exp := align(d, d2)
+	return Decimal{coef: a.Add(a, b), exp: exp}
+}
+
+// Sub returns d - d2
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	a, b, exp := align(d, d2)
+	return Decimal{coef: a.Sub(a, b), exp: exp}
+}
+
+// Mul returns d * d2
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{
+		coef: new(big.Int).Mul(d.value(), d2.value()),
+		exp:  d.exp + d2.exp,
+	}
+}
+
+// Div returns d / d2 rounded half away from zero to DivisionPrecision places,
+// it panics if d2 is zero
+func (d Decimal) Div(d2 Decimal) Decimal {
+	return d.DivRound(d2, DivisionPrecision)
+}
+
+// DivRound returns d / d2 rounded half away from zero to places decimal places,
+// it panics if d2 is zero
+func (d Decimal) DivRound(d2 Decimal, places int32) Decimal {
+	return d.quo(d2, places, roundHalfUp)
+}
+
+type roundingMode int
+
+const (
+	roundHalfUp roundingMode = iota // half away from zero
+	roundDown                       // toward zero
+	roundFloor                      // toward negative infinity
+	roundCeil                       // toward positive infinity
+)
+
+// quo returns d / d2 rounded to places decimal places
+func (d Decimal) quo(d2 Decimal, places int32, mode roundingMode) Decimal {
+	if d2.IsZero() {
+		panic("decimal: division by zero")
+	}
+	num := new(big.Int).Set(d.value())
+	den := new(big.Int).Set(d2.value())
+	// d / d2 = num / den * 10^(d.exp-d2.exp), scaled by 10^places to an integer quotient
+	k := int64(d.exp) - int64(d2.exp) + int64(places)
+	if k >= 0 {
+		num.Mul(num, pow10(k))
+	} else {
+		den.Mul(den, pow10(-k))
+	}
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		sign := num.Sign() * den.Sign()
+		switch mode {
+		case roundHalfUp:
+			r2 := new(big.Int).Abs(r)
+			if r2.Lsh(r2, 1).Cmp(new(big.Int).Abs(den)) >= 0 {
+				q.Add(q, big.NewInt(int64(sign)))
+			}
+		case roundFloor:
+			if sign < 0 {
+				q.Sub(q, bigOne)
+			}
+		case roundCeil:
+			if sign > 0 {
+				q.Add(q, bigOne)
+			}
+		}
+	}
+	return Decimal{coef: q, exp: -places}
+}
+
+// Round rounds d half away from zero to places decimal places
+func (d Decimal) Round(places int32) Decimal {
+	return d.quo(New(1, 0), places, roundHalfUp)
+}
+
+// Truncate drops the decimal places of d after places
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.quo(New(1, 0), places, roundDown)
+}
+
+// RoundStep rounds d to the nearest multiple of step, e.g. a price to the tick size
+func (d Decimal) RoundStep(step Decimal) Decimal {
+	return d.quo(step, 0, roundHalfUp).Mul(step)
+}
+
+// FloorStep rounds d down to a multiple of step, e.g. a qty to the qty step
+func (d Decimal) FloorStep(step Decimal) Decimal {
+	return d.quo(step, 0, roundFloor).Mul(step)
+}
+
+// CeilStep rounds d up to a multiple of step
+func (d Decimal) CeilStep(step Decimal) Decimal {
+	return d.quo(step, 0, roundCeil).Mul(step)
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.value()), exp: d.exp}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.value()), exp: d.exp}
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than d2
+func (d Decimal) Cmp(d2 Decimal) int {
+	a, b, _ := align(d, d2)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d == d2, regardless of their scale
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// LessThan reports whether d < d2
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan reports whether d > d2
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Sign returns -1, 0 or +1
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero reports whether d == 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// IntPart returns the integer part of d, truncated toward zero
+func (d Decimal) IntPart() int64 {
+	return d.Truncate(0).value().Int64()
+}
+
+// Float64 returns the nearest float64 of d
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d in plain notation without trailing zeros, e.g. "3500.5"
+func (d Decimal) String() string {
+	s := d.format()
+	if strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// StringFixed returns d rounded to places decimal places, keeping trailing zeros
+func (d Decimal) StringFixed(places int32) string {
+	return d.Round(places).format()
+}
+
+func (d Decimal) format() string {
+	if d.exp >= 0 {
+		return d.rescale(0).String()
+	}
+	abs := new(big.Int).Abs(d.value()).String()
+	scale := int(-d.exp)
+	if len(abs) <= scale {
+		abs = strings.Repeat("0", scale-len(abs)+1) + abs
+	}
+	s := abs[:len(abs)-scale] + "." + abs[len(abs)-scale:]
+	if d.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// MarshalJSON encodes d as a JSON number
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts JSON numbers and strings, "" and null decode to 0
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := NewFromString(s)
+	if err != nil {
+		return errors.New("decimal: cannot unmarshal " + string(data))
+	}
+	*d = v
+	return nil
+}
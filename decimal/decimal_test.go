@@ -0,0 +1,94 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFromString(t *testing.T) {
+	for s, want := range map[string]string{
+		"35000.50": "35000.5",
+		"-0.0005":  "-0.0005",
+		"1e-8":     "0.00000001",
+		"2.5E+3":   "2500",
+		"+7":       "7",
+		"0.000":    "0",
+	} {
+		d, err := NewFromString(s)
+		if assert.Nil(t, err, s) {
+			assert.Equal(t, want, d.String(), s)
+		}
+	}
+	for _, s := range []string{"", ".", "1.2.3", "--1", "abc", "1e", "NaN", "1e2000000000", "1e1001", "1e-1001"} {
+		_, err := NewFromString(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a := RequireFromString("0.1")
+	b := RequireFromString("0.2")
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.True(t, a.Add(b).Equal(RequireFromString("0.30")))
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "0.5", a.Div(b).String())
+	assert.Equal(t, "0.3333333333333333", NewFromInt(1).Div(NewFromInt(3)).String())
+	assert.Equal(t, "0.67", NewFromInt(2).DivRound(NewFromInt(3), 2).String())
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.True(t, b.GreaterThan(a))
+	assert.True(t, Zero.IsZero())
+	assert.Equal(t, "0.1", a.Neg().Abs().String())
+	assert.Equal(t, int64(-12), RequireFromString("-12.9").IntPart())
+	assert.Equal(t, 0.3, a.Add(b).Float64())
+	assert.Equal(t, "0.1", NewFromFloat(0.1).String())
+	assert.Panics(t, func() { a.Div(Zero) })
+}
+
+func TestDecimal_Rounding(t *testing.T) {
+	assert.Equal(t, "1.24", RequireFromString("1.235").Round(2).String())
+	assert.Equal(t, "-1.24", RequireFromString("-1.235").Round(2).String())
+	assert.Equal(t, "1.23", RequireFromString("1.239").Truncate(2).String())
+	assert.Equal(t, "1300", RequireFromString("1250").Round(-2).String())
+	assert.Equal(t, "2.50", RequireFromString("2.5").StringFixed(2))
+
+	tick := RequireFromString("0.5")
+	assert.Equal(t, "35000.5", RequireFromString("35000.7").RoundStep(tick).String())
+	assert.Equal(t, "35001", RequireFromString("35000.75").RoundStep(tick).String())
+	step := RequireFromString("0.001")
+	assert.Equal(t, "0.123", RequireFromString("0.1239").FloorStep(step).String())
+	assert.Equal(t, "-0.124", RequireFromString("-0.1231").FloorStep(step).String())
+	assert.Equal(t, "0.124", RequireFromString("0.1231").CeilStep(step).String())
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type order struct {
+		Price Decimal `json:"price"`
+		Qty   Decimal `json:"qty"`
+		Fee   Decimal `json:"fee"`
+		Value Decimal `json:"value"`
+	}
+	data := []byte(`{"price":"35000.50","qty":0.001,"fee":"","value":null}`)
+	for name, unmarshal := range map[string]func([]byte, interface{}) error{
+		"encoding/json": json.Unmarshal,
+		"jsoniter":      jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal,
+	} {
+		var o order
+		if assert.Nil(t, unmarshal(data, &o), name) {
+			assert.Equal(t, "35000.5", o.Price.String(), name)
+			assert.Equal(t, "0.001", o.Qty.String(), name)
+			assert.True(t, o.Fee.IsZero(), name)
+			assert.True(t, o.Value.IsZero(), name)
+		}
+	}
+
+	var o order
+	assert.NotNil(t, json.Unmarshal([]byte(`{"price":"x"}`), &o))
+
+	out, err := json.Marshal(order{Price: New(350005, -1)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"price":35000.5,"qty":0,"fee":0,"value":0}`, string(out))
+}
@@ -40,15 +40,15 @@ func main() {
 	// 委托单的更新
 	wsPrivate.Subscribe(ws.WSOrder)
 
-	wsPublic.On(ws.WSOrderBook25L1, handleOrderBook)
-	wsPublic.On(ws.WSTrade, handleTrade)
-	wsPublic.On(ws.WSKLineV2, handleKLineV2)
-	wsPublic.On(ws.WSInsurance, handleInsurance)
-	wsPublic.On(ws.WSInstrument, handleInstrument)
-
-	wsPrivate.On(ws.WSPosition, handlePosition)
-	wsPrivate.On(ws.WSExecution, handleExecution)
-	wsPrivate.On(ws.WSOrder, handleOrder)
+	wsPublic.OnOrderBook(handleOrderBook)
+	wsPublic.OnTrade(handleTrade)
+	wsPublic.OnKLineV2(handleKLineV2)
+	wsPublic.OnInsurance(handleInsurance)
+	wsPublic.OnInstrument(handleInstrument)
+
+	wsPrivate.OnPosition(handlePosition)
+	wsPrivate.OnExecution(handleExecution)
+	wsPrivate.OnOrder(handleOrder)
 
 	wsPublic.Start()
 	wsPrivate.Start()
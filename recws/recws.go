@@ -3,6 +3,7 @@
 package recws
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"log"
@@ -20,6 +21,15 @@ import (
 // a message and the connection is closed
 var ErrNotConnected = errors.New("websocket: not connected")
 
+var (
+	// ErrClosed is the reason of the disconnection by Close
+	ErrClosed = errors.New("websocket: closed")
+	// ErrReconnect is the reason of the disconnection by CloseAndReconnect
+	ErrReconnect = errors.New("websocket: reconnect requested")
+	// ErrKeepAliveTimeout is the reason of the disconnection when pongs stop
+	ErrKeepAliveTimeout = errors.New("websocket: keep alive timeout")
+)
+
 // The RecConn type represents a Reconnecting WebSocket connection.
 type RecConn struct {
 	// RecIntvlMin specifies the initial reconnecting interval,
@@ -46,6 +56,18 @@ type RecConn struct {
 	KeepAliveTimeout time.Duration
 	// NonVerbose suppress connecting/reconnecting messages.
 	NonVerbose bool
+	// MaxAttempts is the number of failed dial attempts in a row after which
+	// connecting is given up, 0 never gives up
+	MaxAttempts int
+	// DialHandler fires before every dial attempt, attempt counting from 1
+	// since the last established connection
+	DialHandler func(attempt int)
+	// DisconnectHandler fires when an established connection is dropped, err
+	// is the read/write error or ErrClosed, ErrReconnect, ErrKeepAliveTimeout
+	DisconnectHandler func(err error)
+	// GiveUpHandler fires when MaxAttempts dial attempts failed in a row, with
+	// the last dial error
+	GiveUpHandler func(err error)
 
 	isConnected bool
 	mu          sync.RWMutex
@@ -54,23 +76,40 @@ type RecConn struct {
 	httpResp    *http.Response
 	dialErr     error
 	dialer      *websocket.Dialer
-	close       chan (bool)
+	shutdown    chan struct{}  // closed by Close, stops reconnecting for good
+	connDone    chan struct{}  // closed when the current connection is dropped
+	wg          sync.WaitGroup // connecting and keep alive goroutines, see Wait
 
 	*websocket.Conn
 }
 
-// CloseAndReconnect will try to reconnect.
+// CloseAndReconnect drops the connection and reconnects in the background.
+// It does nothing when the connection is already dropped, reconnecting then
+// being underway, or closed for good.
 func (rc *RecConn) CloseAndReconnect() {
-	rc.Close()
-	go rc.connect()
+	rc.reconnect(ErrReconnect)
+}
+
+// reconnect drops the connection for reason and reconnects in the background
+func (rc *RecConn) reconnect(reason error) {
+	if rc.closeConn(reason) {
+		rc.goConnect()
+	}
 }
 
-// setIsConnected sets state for isConnected
-func (rc *RecConn) setIsConnected(state bool) {
+// goConnect connects in the background unless closed for good
+func (rc *RecConn) goConnect() {
 	rc.mu.Lock()
 	defer rc.mu.Unlock()
 
-	rc.isConnected = state
+	if rc.shutdownLocked() {
+		return
+	}
+	rc.wg.Add(1)
+	go func() {
+		defer rc.wg.Done()
+		rc.connect()
+	}()
 }
 
 func (rc *RecConn) getConn() *websocket.Conn {
@@ -81,15 +120,60 @@ func (rc *RecConn) getConn() *websocket.Conn {
 }
 
 // Close closes the underlying network connection without
-// sending or waiting for a close frame.
+// sending or waiting for a close frame, and stops reconnecting for good.
 func (rc *RecConn) Close() {
-	if rc.getConn() != nil {
-		rc.mu.Lock()
-		rc.Conn.Close()
+	rc.mu.Lock()
+	if rc.shutdown != nil && !rc.shutdownLocked() {
+		close(rc.shutdown)
+	}
+	rc.mu.Unlock()
+	rc.closeConn(ErrClosed)
+}
+
+// Wait waits for the background goroutines to exit after Close, an ongoing
+// dial being aborted. No handler fires once Wait returns. It must not be
+// called from the handlers.
+func (rc *RecConn) Wait() {
+	rc.wg.Wait()
+}
+
+// closeConn drops the current connection for reason, false if there was none
+func (rc *RecConn) closeConn(reason error) bool {
+	rc.mu.Lock()
+	if !rc.isConnected {
 		rc.mu.Unlock()
+		return false
+	}
+	rc.isConnected = false
+	if rc.Conn != nil {
+		rc.Conn.Close()
+	}
+	if rc.connDone != nil {
+		close(rc.connDone)
+		rc.connDone = nil
+	}
+	rc.mu.Unlock()
+
+	if rc.DisconnectHandler != nil {
+		rc.DisconnectHandler(reason)
+	}
+	return true
+}
+
+func (rc *RecConn) isShutdown() bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	return rc.shutdownLocked()
+}
+
+func (rc *RecConn) shutdownLocked() bool {
+	select {
+	case <-rc.shutdown:
+		return true
+	default:
+		return false
 	}
-	rc.close <- true
-	rc.setIsConnected(false)
 }
 
 // ReadMessage is a helper method for getting a reader
@@ -99,9 +183,9 @@ func (rc *RecConn) Close() {
 func (rc *RecConn) ReadMessage() (messageType int, message []byte, err error) {
 	err = ErrNotConnected
 	if rc.IsConnected() {
-		messageType, message, err = rc.Conn.ReadMessage()
+		messageType, message, err = rc.getConn().ReadMessage()
 		if err != nil {
-			rc.CloseAndReconnect()
+			rc.reconnect(err)
 		}
 	}
 
@@ -119,7 +203,7 @@ func (rc *RecConn) WriteMessage(messageType int, data []byte) error {
 		err = rc.Conn.WriteMessage(messageType, data)
 		rc.mu.Unlock()
 		if err != nil {
-			rc.CloseAndReconnect()
+			rc.reconnect(err)
 		}
 	}
 
@@ -139,7 +223,7 @@ func (rc *RecConn) WriteJSON(v interface{}) error {
 		err = rc.Conn.WriteJSON(v)
 		rc.mu.Unlock()
 		if err != nil {
-			rc.CloseAndReconnect()
+			rc.reconnect(err)
 		}
 	}
 
@@ -156,9 +240,9 @@ func (rc *RecConn) WriteJSON(v interface{}) error {
 func (rc *RecConn) ReadJSON(v interface{}) error {
 	err := ErrNotConnected
 	if rc.IsConnected() {
-		err = rc.Conn.ReadJSON(v)
+		err = rc.getConn().ReadJSON(v)
 		if err != nil {
-			rc.CloseAndReconnect()
+			rc.reconnect(err)
 		}
 	}
 
@@ -292,7 +376,9 @@ func (rc *RecConn) Dial(urlStr string, reqHeader http.Header) {
 	}
 
 	// Close channel
-	rc.close = make(chan bool, 1)
+	rc.mu.Lock()
+	rc.shutdown = make(chan struct{})
+	rc.mu.Unlock()
 
 	// Config
 	rc.setURL(urlStr)
@@ -305,7 +391,7 @@ func (rc *RecConn) Dial(urlStr string, reqHeader http.Header) {
 	rc.setDefaultDialer(rc.getTLSClientConfig(), rc.getHandshakeTimeout())
 
 	// Connect
-	go rc.connect()
+	rc.goConnect()
 
 	// wait on first attempt
 	time.Sleep(rc.getHandshakeTimeout())
@@ -359,7 +445,8 @@ func (rc *RecConn) writeControlPingMessage() error {
 	return rc.Conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(10*time.Second))
 }
 
-func (rc *RecConn) keepAlive() {
+// keepAlive pings the connection until connDone is closed
+func (rc *RecConn) keepAlive(connDone chan struct{}) {
 	var (
 		keepAliveResponse = new(keepAliveResponse)
 		ticker            = time.NewTicker(rc.getKeepAliveTimeout())
@@ -372,21 +459,23 @@ func (rc *RecConn) keepAlive() {
 	})
 	rc.mu.Unlock()
 
+	rc.wg.Add(1)
 	go func() {
+		defer rc.wg.Done()
 		defer ticker.Stop()
 
 		for {
-			if !rc.IsConnected() {
-				return
-			}
-
 			if err := rc.writeControlPingMessage(); err != nil {
 				log.Printf("BybitRecws KeepAlive Error: %s", err.Error())
 			}
 
-			<-ticker.C
+			select {
+			case <-connDone:
+				return
+			case <-ticker.C:
+			}
 			if time.Since(keepAliveResponse.getLastResponse()) > rc.getKeepAliveTimeout() {
-				rc.CloseAndReconnect()
+				rc.reconnect(ErrKeepAliveTimeout)
 				return
 			}
 		}
@@ -397,16 +486,47 @@ func (rc *RecConn) connect() {
 	b := rc.getBackoff()
 	rand.Seed(time.Now().UTC().UnixNano())
 
-	for {
+	rc.mu.RLock()
+	shutdown := rc.shutdown
+	rc.mu.RUnlock()
+
+	// aborts the dial on Close
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for attempt := 1; ; attempt++ {
 		select {
-		case <-rc.close:
+		case <-shutdown:
 			return
 		default:
 			nextItvl := b.Duration()
-			wsConn, httpResp, err := rc.dialer.Dial(rc.url, rc.reqHeader)
+			if rc.DialHandler != nil && !rc.isShutdown() {
+				rc.DialHandler(attempt)
+			}
+			wsConn, httpResp, err := rc.dialer.DialContext(ctx, rc.url, rc.reqHeader)
 
 			rc.mu.Lock()
-			rc.Conn = wsConn
+			if rc.shutdownLocked() {
+				// closed while dialing
+				rc.mu.Unlock()
+				if err == nil {
+					wsConn.Close()
+				}
+				return
+			}
+			var connDone chan struct{}
+			if err == nil {
+				connDone = make(chan struct{})
+				rc.Conn = wsConn
+				rc.connDone = connDone
+			}
 			rc.dialErr = err
 			rc.isConnected = err == nil
 			rc.httpResp = httpResp
@@ -427,7 +547,17 @@ func (rc *RecConn) connect() {
 				}
 
 				if rc.getKeepAliveTimeout() != 0 {
-					rc.keepAlive()
+					rc.keepAlive(connDone)
+				}
+				return
+			}
+
+			if rc.MaxAttempts > 0 && attempt >= rc.MaxAttempts {
+				if !rc.getNonVerbose() {
+					log.Printf("BybitRecws Dial: giving up after %v attempts. Error: %s", attempt, err.Error())
+				}
+				if rc.GiveUpHandler != nil {
+					rc.GiveUpHandler(err)
 				}
 				return
 			}
@@ -436,7 +566,11 @@ func (rc *RecConn) connect() {
 				log.Printf("BybitRecws Dial: will try again in %v seconds. Error: %s", nextItvl, err.Error())
 			}
 
-			time.Sleep(nextItvl)
+			select {
+			case <-shutdown:
+				return
+			case <-time.After(nextItvl):
+			}
 		}
 	}
 }
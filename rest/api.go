@@ -2,15 +2,18 @@ package rest
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
+	sjson "encoding/json"
+	"errors"
 	"fmt"
 	"github.com/json-iterator/go"
+	"github.com/wilcosheh/bybit-api/signer"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -19,38 +22,88 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 // Bybit
 type ByBit struct {
-	baseURL          string // https://api-testnet.bybit.com/open-api/
+	hosts            *hostSet
 	apiKey           string
-	secretKey        string
-	serverTimeOffset int64
+	signer           signer.Signer
 	client           *http.Client
 	debugMode        bool
+	ctx              context.Context
+	rateLimits       *rateLimitTracker
+	limiter          *RateLimiter
+	retryPolicy      *RetryPolicy
+	clock            *serverClock
+	recvWindow       int
+	instruments      *instrumentCache
+	validation       OrderValidation
+	batchConcurrency int
 }
 
-// New
+// New creates a client signing with the HMAC secret of a system-generated api key,
+// baseURL is its main host e.g. https://api-testnet.bybit.com/, see NewWithEnvironment
 func New(httpClient *http.Client, baseURL string, apiKey string, secretKey string, debugMode bool) *ByBit {
+	return NewWithSigner(httpClient, baseURL, apiKey, signer.NewHMAC(secretKey), debugMode)
+}
+
+// NewWithSigner creates a client whose signed requests are signed by s, e.g. a
+// signer.RSA for self-generated RSA keys or a signer.Func calling a signing daemon
+func NewWithSigner(httpClient *http.Client, baseURL string, apiKey string, s signer.Signer, debugMode bool) *ByBit {
 	if httpClient == nil {
 		httpClient = &http.Client{
 			Timeout: 10 * time.Second,
 		}
 	}
 	return &ByBit{
-		baseURL:   baseURL,
-		apiKey:    apiKey,
-		secretKey: secretKey,
-		client:    httpClient,
-		debugMode: debugMode,
+		hosts:       newHostSet(Environment{HostMain: {baseURL}}),
+		apiKey:      apiKey,
+		signer:      s,
+		client:      httpClient,
+		debugMode:   debugMode,
+		rateLimits:  newRateLimitTracker(),
+		clock:       newServerClock(),
+		instruments: newInstrumentCache(),
 	}
 }
 
-// SetCorrectServerTime
-func (b *ByBit) SetCorrectServerTime() (err error) {
-	var timeNow int64
-	_, _, timeNow, err = b.GetServerTime()
-	if err != nil {
-		return
+// WithContext returns a shallow copy of b whose requests are bound to ctx,
+// so every endpoint can be given a deadline or cancelled:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	_, _, order, err := b.WithContext(ctx).LinearCreateOrder(...)
+func (b *ByBit) WithContext(ctx context.Context) *ByBit {
+	if ctx == nil {
+		panic("bybit: nil context")
 	}
-	b.serverTimeOffset = timeNow - time.Now().UnixNano()/1e6
+	b2 := *b
+	b2.ctx = ctx
+	return &b2
+}
+
+// Context returns the context requests are bound to, context.Background by default.
+func (b *ByBit) Context() context.Context {
+	if b.ctx != nil {
+		return b.ctx
+	}
+	return context.Background()
+}
+
+// SetRecvWindow sets the default recv_window in milliseconds sent with every signed
+// request, 0 leaves it to the exchange default (5000)
+func (b *ByBit) SetRecvWindow(recvWindow int) {
+	b.recvWindow = recvWindow
+}
+
+// WithRecvWindow returns a shallow copy of b whose signed requests use recvWindow
+// milliseconds, e.g. b.WithRecvWindow(1000).LinearCreateOrder(...)
+func (b *ByBit) WithRecvWindow(recvWindow int) *ByBit {
+	b2 := *b
+	b2.recvWindow = recvWindow
+	return &b2
+}
+
+// SetCorrectServerTime syncs the server time once, see StartServerTimeSync to keep it in sync
+func (b *ByBit) SetCorrectServerTime() (err error) {
+	_, _, err = b.SyncServerTime(1)
 	return
 }
 
@@ -64,52 +117,67 @@ func (b *ByBit) PublicRequest(method string, apiURL string, params map[string]in
 
 	var p []string
 	for _, k := range keys {
-		p = append(p, fmt.Sprintf("%v=%v", k, params[k]))
+		p = append(p, k+"="+formatParam(params[k]))
 	}
 
-	param := strings.Join(p, "&")
-	fullURL = b.baseURL + apiURL
-	if param != "" {
-		fullURL += "?" + param
-	}
-	if b.debugMode {
-		log.Printf("PublicRequest: %v", fullURL)
+	path := apiURL
+	if param := strings.Join(p, "&"); param != "" {
+		path += "?" + param
 	}
-	var binBody = bytes.NewReader(make([]byte, 0))
-
-	// get a http request
-	var request *http.Request
-	request, err = http.NewRequest(method, fullURL, binBody)
+	err = b.retry(method == http.MethodGet, func(int) error {
+		var e error
+		fullURL, resp, e = b.sendRequest(method, apiURL, path, nil)
+		if b.debugMode {
+			log.Printf("PublicRequest: %v", fullURL)
+		}
+		if b.debugMode && resp != nil {
+			log.Printf("PublicRequest: %v", string(resp))
+		}
+		return e
+	})
 	if err != nil {
 		return
 	}
 
-	var response *http.Response
-	response, err = b.client.Do(request)
-	if err != nil {
-		return
-	}
-	defer response.Body.Close()
+	err = json.Unmarshal(resp, result)
+	return
+}
 
-	resp, err = ioutil.ReadAll(response.Body)
+// SignedRequest sends params signed in the query string
+func (b *ByBit) SignedRequest(method string, apiURL string, params map[string]interface{}, result interface{}) (fullURL string, resp []byte, err error) {
+	return b.doSignedRequest(method, apiURL, params, result, false)
+}
+
+// SignedJSONRequest sends params signed in a json body, which keeps order details
+// out of urls and proxy logs. GET requests are still sent as query string.
+func (b *ByBit) SignedJSONRequest(method string, apiURL string, params map[string]interface{}, result interface{}) (fullURL string, resp []byte, err error) {
+	return b.doSignedRequest(method, apiURL, params, result, method != http.MethodGet)
+}
+
+func (b *ByBit) doSignedRequest(method string, apiURL string, params map[string]interface{}, result interface{}, jsonBody bool) (fullURL string, resp []byte, err error) {
+	err = b.retry(method == http.MethodGet, func(int) error {
+		var e error
+		fullURL, resp, e = b.signedRequest(method, apiURL, params, jsonBody)
+		return e
+	})
 	if err != nil {
 		return
 	}
-
-	if b.debugMode {
-		log.Printf("PublicRequest: %v", string(resp))
-	}
-
 	err = json.Unmarshal(resp, result)
 	return
 }
 
-// SignedRequest
-func (b *ByBit) SignedRequest(method string, apiURL string, params map[string]interface{}, result interface{}) (fullURL string, resp []byte, err error) {
-	timestamp := time.Now().UnixNano()/1e6 + b.serverTimeOffset
+// signedRequest signs params with a fresh timestamp and sends them once
+func (b *ByBit) signedRequest(method string, apiURL string, params map[string]interface{}, jsonBody bool) (fullURL string, resp []byte, err error) {
+	timestamp := b.clock.timestamp()
 
 	params["api_key"] = b.apiKey
 	params["timestamp"] = timestamp
+	if b.recvWindow > 0 {
+		params["recv_window"] = b.recvWindow
+	} else {
+		delete(params, "recv_window")
+	}
 
 	var keys []string
 	for k := range params {
@@ -119,25 +187,116 @@ func (b *ByBit) SignedRequest(method string, apiURL string, params map[string]in
 
 	var p []string
 	for _, k := range keys {
-		p = append(p, fmt.Sprintf("%v=%v", k, params[k]))
+		p = append(p, k+"="+formatParam(params[k]))
 	}
 
 	param := strings.Join(p, "&")
-	signature := b.getSigned(param)
-	param += "&sign=" + signature
+	var signature string
+	signature, err = b.getSigned(param)
+	if err != nil {
+		return
+	}
 
-	fullURL = b.baseURL + apiURL + "?" + param
+	var body []byte
+	path := apiURL
+	if jsonBody {
+		payload := make(map[string]interface{}, len(params)+1)
+		for k, v := range params {
+			payload[k] = jsonParam(v)
+		}
+		payload["sign"] = signature
+		// encoding/json: jsoniter's map encoder depends on reflect2 internals
+		body, err = sjson.Marshal(payload)
+		if err != nil {
+			return
+		}
+	} else {
+		path += "?" + param + "&sign=" + url.QueryEscape(signature)
+	}
+	fullURL, resp, err = b.sendRequest(method, apiURL, path, body)
 	if b.debugMode {
-		log.Printf("SignedRequest: %v", fullURL)
+		log.Printf("SignedRequest: %v %v", fullURL, string(body))
+	}
+	if b.debugMode && resp != nil {
+		log.Printf("SignedRequest: %v", string(resp))
+	}
+	if IsTimestampExpired(err) {
+		b.clock.requestResync()
+	}
+	return
+}
+
+// formatParam formats a parameter for the signature payload, floats without exponent
+func formatParam(v interface{}) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// jsonParam returns a parameter as encoded in a json body, floats formatted as
+// signed by formatParam as encoding/json would write exponents for some of them
+func jsonParam(v interface{}) interface{} {
+	switch v.(type) {
+	case float64, float32:
+		return sjson.Number(formatParam(v))
 	}
-	var binBody = bytes.NewReader(make([]byte, 0))
+	return v
+}
+
+// sendRequest performs the http round trip of endpoint apiURL bound to b's context,
+// path is apiURL with its query string and a non-nil body is sent as json.
+// It applies the client side rate limiter, records the returned quota and
+// turns error responses into *APIError while still returning the raw body.
+// The request goes to the host of apiURL, failing over to its mirrors when
+// unreachable, and fullURL is the url it was last sent to.
+func (b *ByBit) sendRequest(method string, apiURL string, path string, body []byte) (fullURL string, resp []byte, err error) {
+	ctx := b.Context()
+	if b.limiter != nil {
+		state, known := b.rateLimits.get(apiURL)
+		if err = b.limiter.wait(ctx, apiURL, state, known); err != nil {
+			return
+		}
+	}
+
+	host, bases := b.hosts.bases(endpointHost(apiURL))
+	if len(bases) == 0 {
+		err = fmt.Errorf("bybit: no base url for host %v", host)
+		return
+	}
+	for i, base := range bases {
+		fullURL = base + path
+		resp, err = b.roundTrip(ctx, method, apiURL, fullURL, body)
+		if i+1 < len(bases) && shouldFailover(ctx, method, err) {
+			if b.debugMode {
+				log.Printf("Failover: %v unreachable, trying %v: %v", base, bases[i+1], err)
+			}
+			continue
+		}
+		if i > 0 {
+			b.hosts.use(host, base)
+		}
+		return
+	}
+	return
+}
+
+// roundTrip sends a request to fullURL once
+func (b *ByBit) roundTrip(ctx context.Context, method string, apiURL string, fullURL string, body []byte) (resp []byte, err error) {
+	var binBody = bytes.NewReader(body)
 
 	// get a http request
 	var request *http.Request
-	request, err = http.NewRequest(method, fullURL, binBody)
+	request, err = http.NewRequestWithContext(ctx, method, fullURL, binBody)
 	if err != nil {
 		return
 	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
 
 	var response *http.Response
 	response, err = b.client.Do(request)
@@ -151,17 +310,16 @@ func (b *ByBit) SignedRequest(method string, apiURL string, params map[string]in
 		return
 	}
 
-	if b.debugMode {
-		log.Printf("SignedRequest: %v", string(resp))
-	}
-	err = json.Unmarshal(resp, result)
+	status := parseRetStatus(resp)
+	b.rateLimits.observe(apiURL, response.Header, status)
+	err = checkResponse(apiURL, response.StatusCode, status)
 	return
 }
 
 // getSigned
-func (b *ByBit) getSigned(param string) string {
-	sig := hmac.New(sha256.New, []byte(b.secretKey))
-	sig.Write([]byte(param))
-	signature := hex.EncodeToString(sig.Sum(nil))
-	return signature
+func (b *ByBit) getSigned(param string) (string, error) {
+	if b.signer == nil {
+		return "", errors.New("bybit: signed request without signer")
+	}
+	return b.signer.Sign([]byte(param))
 }
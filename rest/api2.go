@@ -4,7 +4,8 @@ import (
 	"net/http"
 )
 
-// To use this functions will need to set b.BaseUrl to api2.bybit.com or api2-testnet.bybit.com
+// These functions are sent to HostAPI2, api2.bybit.com or api2-testnet.bybit.com, see SetHost
+// The funding rates of the main API are GetPrevFundingRate and LinearGetPrevFundingRate
 
 // GetFunding
 // https://api2.bybit.com/funding-rate/list?symbol=BTCUSD&date=&export=false&page=1&limit=20
@@ -55,10 +55,6 @@ func (b *ByBit) GetPositions() (query string, resp []byte, result []PositionData
 	if err != nil {
 		return
 	}
-	if r.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", r.RetMsg, string(resp))
-		return
-	}
 	result = r.Result
 	return
 }
@@ -72,10 +68,6 @@ func (b *ByBit) GetPosition(symbol string) (query string, resp []byte, result Po
 	if err != nil {
 		return
 	}
-	if r.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", r.RetMsg, string(resp))
-		return
-	}
 	result = r.Result
 	return
 }
@@ -86,7 +78,7 @@ func (b *ByBit) SetLeverage(leverage int, symbol string) (query string, resp []b
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	params["leverage"] = fmt.Sprintf("%v", leverage)
-	query, resp, err = b.SignedRequest(http.MethodPost, "user/leverage/save", params, &r)
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "user/leverage/save", params, &r)
 	if err != nil {
 		return
 	}
@@ -110,10 +102,6 @@ func (b *ByBit) WalletRecords(symbol string, page int, limit int) (query string,
 	if err != nil {
 		return
 	}
-	if r.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", r.RetMsg, string(resp))
-		return
-	}
 	result = r.Result.Data
 	return
 }
@@ -1,7 +1,8 @@
 package rest
 
 import (
-	"fmt"
+	"github.com/wilcosheh/bybit-api/decimal"
+	"math"
 	"net/http"
 	"sort"
 	"strconv"
@@ -31,10 +32,10 @@ func (b *ByBit) GetOrderBook(symbol string) (query string, resp []byte, result O
 		}
 	}
 	sort.Slice(result.Asks, func(i, j int) bool {
-		return result.Asks[i].Price < result.Asks[j].Price
+		return result.Asks[i].Price.LessThan(result.Asks[j].Price)
 	})
 	sort.Slice(result.Bids, func(i, j int) bool {
-		return result.Bids[i].Price > result.Bids[j].Price
+		return result.Bids[i].Price.GreaterThan(result.Bids[j].Price)
 	})
 	var timeNow float64
 	timeNow, err = strconv.ParseFloat(ret.TimeNow, 64) // 1582011750.433202
@@ -85,10 +86,6 @@ func (b *ByBit) GetOrders(symbol string, orderStatus string, direction string, l
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult.Result
 	return
 }
@@ -102,93 +99,107 @@ func (b *ByBit) GetActiveOrders(symbol string) (query string, resp []byte, resul
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult
 	return
 }
 
-// CreateOrder
-func (b *ByBit) CreateOrder(side string, orderType string, price float64,
-	qty int, timeInForce string, takeProfit float64, stopLoss float64, reduceOnly bool,
-	closeOnTrigger bool, orderLinkID string, symbol string) (query string, resp []byte, result Order, err error) {
+// GetActiveOrder Query real-time active order information. If only order_id or order_link_id are passed, a single order will be returned.
+func (b *ByBit) GetActiveOrder(symbol string, orderID string, orderLinkID string) (query string, resp []byte, result OrderResponse, err error) {
 	var cResult OrderResponse
 	params := map[string]interface{}{}
-	params["side"] = side
 	params["symbol"] = symbol
-	params["order_type"] = orderType
-	params["qty"] = qty
-	if price > 0 {
-		params["price"] = price
-	}
-	params["time_in_force"] = timeInForce
-	if takeProfit > 0 {
-		params["take_profit"] = takeProfit
-	}
-	if stopLoss > 0 {
-		params["stop_loss"] = stopLoss
-	}
-	if reduceOnly {
-		params["reduce_only"] = true
-	}
-	if closeOnTrigger {
-		params["close_on_trigger"] = true
+	if orderID != "" {
+		params["order_id"] = orderID
 	}
 	if orderLinkID != "" {
 		params["order_link_id"] = orderLinkID
 	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/order/create", params, &cResult)
+	query, resp, err = b.SignedRequest(http.MethodGet, "v2/private/order", params, &cResult)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	result = cResult
+	return
+}
+
+// CreateOrder, see PlaceOrder
+func (b *ByBit) CreateOrder(side string, orderType string, price float64,
+	qty int, timeInForce string, takeProfit float64, stopLoss float64, reduceOnly bool,
+	closeOnTrigger bool, orderLinkID string, symbol string) (query string, resp []byte, result Order, err error) {
+	return b.PlaceOrder(CreateOrderRequest{
+		Symbol:         symbol,
+		Side:           Side(side),
+		OrderType:      OrderType(orderType),
+		Qty:            qty,
+		Price:          price,
+		TimeInForce:    TimeInForce(timeInForce),
+		TakeProfit:     takeProfit,
+		StopLoss:       stopLoss,
+		ReduceOnly:     reduceOnly,
+		CloseOnTrigger: closeOnTrigger,
+		OrderLinkID:    orderLinkID,
+	})
+}
+
+// PlaceOrder creates an inverse perpetual order, checked first against the
+// SymbolInfo filters when SetOrderValidation is enabled
+// A failed call carrying OrderLinkID is retried according to the retry policy,
+// the order is looked up by OrderLinkID before being resent.
+func (b *ByBit) PlaceOrder(req CreateOrderRequest) (query string, resp []byte, result Order, err error) {
+	qty := float64(req.Qty)
+	if err = b.preflightOrder(req.Symbol, &qty, &req.Price, &req.TakeProfit, &req.StopLoss); err != nil {
 		return
 	}
-	result = cResult.Result
-	return
+	req.Qty = int(math.Round(qty))
+	return b.placeOrder("v2/private/order/create", req.params(), req.Symbol, req.OrderLinkID, false)
 }
 
 // ReplaceOrder
 func (b *ByBit) ReplaceOrder(symbol string, orderID string, qty int, price float64) (query string, resp []byte, result Order, err error) {
+	return b.replaceOrder(symbol, orderID, "", qty, price)
+}
+
+func (b *ByBit) replaceOrder(symbol string, orderID string, orderLinkID string, qty int, price float64) (query string, resp []byte, result Order, err error) {
 	var cResult OrderResponse
 	params := map[string]interface{}{}
-	params["order_id"] = orderID
 	params["symbol"] = symbol
+	if orderID != "" {
+		params["order_id"] = orderID
+	}
+	if orderLinkID != "" {
+		params["order_link_id"] = orderLinkID
+	}
 	if qty > 0 {
 		params["p_r_qty"] = qty
 	}
 	if price > 0 {
 		params["p_r_price"] = price
 	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/order/replace", params, &cResult)
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/order/replace", params, &cResult)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result.OrderId = cResult.Result.OrderId
 	return
 }
 
 // CancelOrder
 func (b *ByBit) CancelOrder(orderID string, symbol string) (query string, resp []byte, result Order, err error) {
+	return b.cancelOrder("v2/private/order/cancel", symbol, orderID, "")
+}
+
+func (b *ByBit) cancelOrder(apiURL string, symbol string, orderID string, orderLinkID string) (query string, resp []byte, result Order, err error) {
 	var cResult OrderResponse
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	if orderID != "" {
 		params["order_id"] = orderID
 	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/order/cancel", params, &cResult)
-	if err != nil {
-		return
+	if orderLinkID != "" {
+		params["order_link_id"] = orderLinkID
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, apiURL, params, &cResult)
+	if err != nil {
 		return
 	}
 	result = cResult.Result
@@ -200,14 +211,10 @@ func (b *ByBit) CancelAllOrder(symbol string) (query string, resp []byte, result
 	var cResult OrderArrayResponse
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/order/cancelAll", params, &cResult)
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/order/cancelAll", params, &cResult)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult.Result
 	return
 }
@@ -234,10 +241,6 @@ func (b *ByBit) GetStopOrders(symbol string, stopOrderStatus string, direction s
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult.Result
 	return
 }
@@ -251,10 +254,6 @@ func (b *ByBit) GetActiveStopOrders(symbol string) (query string, resp []byte, r
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult
 	return
 }
@@ -262,30 +261,30 @@ func (b *ByBit) GetActiveStopOrders(symbol string) (query string, resp []byte, r
 // CreateStopOrder
 func (b *ByBit) CreateStopOrder(side string, orderType string, price float64, basePrice float64, stopPx float64,
 	qty int, triggerBy string, timeInForce string, closeOnTrigger bool, symbol string) (query string, resp []byte, result StopOrder, err error) {
+	return b.PlaceStopOrder(StopOrderRequest{
+		Symbol:         symbol,
+		Side:           Side(side),
+		OrderType:      OrderType(orderType),
+		Qty:            qty,
+		Price:          price,
+		BasePrice:      basePrice,
+		StopPx:         stopPx,
+		TriggerBy:      TriggerBy(triggerBy),
+		TimeInForce:    TimeInForce(timeInForce),
+		CloseOnTrigger: closeOnTrigger,
+	})
+}
+
+// PlaceStopOrder creates a conditional order
+func (b *ByBit) PlaceStopOrder(req StopOrderRequest) (query string, resp []byte, result StopOrder, err error) {
 	var cResult StopOrderResponse
-	params := map[string]interface{}{}
-	params["side"] = side
-	params["symbol"] = symbol
-	params["order_type"] = orderType
-	params["qty"] = qty
-	if price > 0 {
-		params["price"] = price
-	}
-	params["base_price"] = basePrice
-	params["stop_px"] = stopPx
-	params["time_in_force"] = timeInForce
-	if closeOnTrigger {
-		params["close_on_trigger"] = true
-	}
-	if triggerBy != "" {
-		params["trigger_by"] = triggerBy
-	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/stop-order/create", params, &cResult)
-	if err != nil {
+	qty := float64(req.Qty)
+	if err = b.preflightOrder(req.Symbol, &qty, &req.Price, &req.StopPx, &req.TakeProfit, &req.StopLoss); err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	req.Qty = int(math.Round(qty))
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/stop-order/create", req.params(), &cResult)
+	if err != nil {
 		return
 	}
 	result = cResult.Result
@@ -294,25 +293,24 @@ func (b *ByBit) CreateStopOrder(side string, orderType string, price float64, ba
 
 // ReplaceStopOrder
 func (b *ByBit) ReplaceStopOrder(symbol string, orderID string, qty int, price float64, triggerPrice float64) (query string, resp []byte, result StopOrder, err error) {
+	return b.AmendStopOrder(ReplaceStopOrderRequest{
+		Symbol:       symbol,
+		StopOrderID:  orderID,
+		Qty:          float64(qty),
+		Price:        price,
+		TriggerPrice: triggerPrice,
+	})
+}
+
+// AmendStopOrder amends an untriggered conditional order, by stop_order_id or order_link_id
+func (b *ByBit) AmendStopOrder(req ReplaceStopOrderRequest) (query string, resp []byte, result StopOrder, err error) {
 	var cResult StopOrderResponse
-	params := map[string]interface{}{}
-	params["stop_order_id"] = orderID
-	params["symbol"] = symbol
-	if qty > 0 {
-		params["p_r_qty"] = qty
-	}
-	if price > 0 {
-		params["p_r_price"] = price
-	}
-	if triggerPrice > 0 {
-		params["p_r_trigger_price"] = triggerPrice
-	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/stop-order/replace", params, &cResult)
+	params, err := req.params(false)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/stop-order/replace", params, &cResult)
+	if err != nil {
 		return
 	}
 	result.StopOrderId = cResult.Result.StopOrderId
@@ -321,16 +319,23 @@ func (b *ByBit) ReplaceStopOrder(symbol string, orderID string, qty int, price f
 
 // CancelStopOrder
 func (b *ByBit) CancelStopOrder(orderID string, symbol string) (query string, resp []byte, result StopOrder, err error) {
+	return b.cancelStopOrder("v2/private/stop-order/cancel", symbol, orderID, "")
+}
+
+// CancelStopOrderByLinkID cancels an untriggered conditional order by its order_link_id
+func (b *ByBit) CancelStopOrderByLinkID(orderLinkID string, symbol string) (query string, resp []byte, result StopOrder, err error) {
+	return b.cancelStopOrder("v2/private/stop-order/cancel", symbol, "", orderLinkID)
+}
+
+func (b *ByBit) cancelStopOrder(apiURL string, symbol string, stopOrderID string, orderLinkID string) (query string, resp []byte, result StopOrder, err error) {
 	var cResult StopOrderResponse
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
-	params["stop_order_id"] = orderID
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/stop-order/cancel", params, &cResult)
-	if err != nil {
+	if err = stopOrderRef(params, stopOrderID, orderLinkID); err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, apiURL, params, &cResult)
+	if err != nil {
 		return
 	}
 	result.StopOrderId = cResult.Result.StopOrderId
@@ -342,14 +347,186 @@ func (b *ByBit) CancelAllStopOrders(symbol string) (query string, resp []byte, r
 	var cResult StopOrderArrayResponse
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
-	query, resp, err = b.SignedRequest(http.MethodPost, "v2/private/stop-order/cancelAll", params, &cResult)
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/stop-order/cancelAll", params, &cResult)
+	if err != nil {
+		return
+	}
+	result = cResult.Result
+	return
+}
+
+// ChangePositionMargin adds margin to, or with a negative margin removes margin from, an isolated position
+func (b *ByBit) ChangePositionMargin(symbol string, margin float64) (query string, resp []byte, result decimal.Decimal, err error) {
+	var r ChangePositionMarginResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	params["margin"] = margin
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/position/change-position-margin", params, &r)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	result = r.Result
+	return
+}
+
+// SetTradingStop sets the take profit, stop loss or trailing stop of a position
+func (b *ByBit) SetTradingStop(req TradingStopRequest) (query string, resp []byte, err error) {
+	var r BaseResult
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/position/trading-stop", req.params(false), &r)
+	return
+}
+
+// SwitchIsolated switches a position between cross and isolated margin
+func (b *ByBit) SwitchIsolated(req SwitchIsolatedRequest) (query string, resp []byte, err error) {
+	var r BaseResult
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/position/switch-isolated", req.params(), &r)
+	return
+}
+
+// SwitchPositionMode switches symbol between one-way and hedge mode
+func (b *ByBit) SwitchPositionMode(symbol string, mode PositionMode) (query string, resp []byte, err error) {
+	var r BaseResult
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	// 0: one-way, 3: hedge
+	if mode == PositionModeBothSide {
+		params["mode"] = 3
+	} else {
+		params["mode"] = 0
+	}
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/position/switch-mode", params, &r)
+	return
+}
+
+// GetRiskLimits returns the risk limits of symbol
+func (b *ByBit) GetRiskLimits(symbol string) (query string, resp []byte, result []RiskLimit, err error) {
+	var r RiskLimitArrayResponse
+	params := map[string]interface{}{}
+	if symbol != "" {
+		params["symbol"] = symbol
+	}
+	query, resp, err = b.PublicRequest(http.MethodGet, "v2/public/risk-limit/list", params, &r)
+	if err != nil {
 		return
 	}
-	result = cResult.Result
+	result = r.Result
+	return
+}
+
+// SetRiskLimit sets the risk limit of a position, riskID from GetRiskLimits
+func (b *ByBit) SetRiskLimit(symbol string, riskID int) (query string, resp []byte, result SetRiskLimitResult, err error) {
+	var r SetRiskLimitResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	params["risk_id"] = riskID
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "v2/private/position/risk-limit", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// GetExecutions returns the fills of symbol, or of orderID, newest first
+// startTime: optional, milliseconds
+func (b *ByBit) GetExecutions(symbol string, orderID string, startTime int64, page int, limit int) (query string, resp []byte, result []Execution, err error) {
+	var r ExecutionListResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	if orderID != "" {
+		params["order_id"] = orderID
+	}
+	if startTime > 0 {
+		params["start_time"] = startTime
+	}
+	if page > 0 {
+		params["page"] = page
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	params["order"] = "desc"
+	query, resp, err = b.SignedRequest(http.MethodGet, "v2/private/execution/list", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result.TradeList
+	for i := range result {
+		result[i].normalize()
+	}
+	return
+}
+
+// GetClosedPnl returns the closed PnL records of symbol, newest first
+// startTime, endTime: optional, seconds
+// execType: optional, Trade/AdlTrade/Funding/BustTrade
+func (b *ByBit) GetClosedPnl(symbol string, startTime int64, endTime int64, execType string, page int, limit int) (query string, resp []byte, result []ClosedPnl, err error) {
+	var r ClosedPnlResponse
+	params := closedPnlParams(symbol, startTime, endTime, execType, page, limit)
+	query, resp, err = b.SignedRequest(http.MethodGet, "v2/private/trade/closed-pnl/list", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result.Data
+	return
+}
+
+func closedPnlParams(symbol string, startTime int64, endTime int64, execType string, page int, limit int) map[string]interface{} {
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	if startTime > 0 {
+		params["start_time"] = startTime
+	}
+	if endTime > 0 {
+		params["end_time"] = endTime
+	}
+	if execType != "" {
+		params["exec_type"] = execType
+	}
+	if page > 0 {
+		params["page"] = page
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	return params
+}
+
+// GetPrevFundingRate returns the last settled funding rate of symbol
+func (b *ByBit) GetPrevFundingRate(symbol string) (query string, resp []byte, result FundingRate, err error) {
+	var r FundingRateResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	query, resp, err = b.PublicRequest(http.MethodGet, "v2/public/funding/prev-funding-rate", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// GetMyLastFundingFee returns the funding fee of the last settlement of symbol
+func (b *ByBit) GetMyLastFundingFee(symbol string) (query string, resp []byte, result FundingFee, err error) {
+	var r FundingFeeResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	query, resp, err = b.SignedRequest(http.MethodGet, "v2/private/funding/prev-funding", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// GetPredictedFunding returns the funding rate and fee of the next settlement of symbol
+func (b *ByBit) GetPredictedFunding(symbol string) (query string, resp []byte, result PredictedFunding, err error) {
+	var r PredictedFundingResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	query, resp, err = b.SignedRequest(http.MethodGet, "v2/private/funding/predicted-funding", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
 	return
 }
@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"fmt"
 	"net/http"
 )
 
@@ -48,10 +47,6 @@ func (b *ByBit) LinearGetOrders(symbol string, orderStatus string, limit int, pa
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult.Result
 	return
 }
@@ -65,10 +60,6 @@ func (b *ByBit) LinearGetActiveOrders(symbol string) (query string, resp []byte,
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult
 	return
 }
@@ -88,53 +79,42 @@ func (b *ByBit) LinearGetActiveOrder(symbol string, orderId string, orderLinkId
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult
 	return
 }
 
-// LinearCreateOrder CreateOrder
+// LinearCreateOrder CreateOrder, see LinearPlaceOrder
 // side: Buy/Sell
 // orderType: Limit/Market
 // timeInForce: GoodTillCancel/ImmediateOrCancel/FillOrKill/PostOnly
 func (b *ByBit) LinearCreateOrder(side string, orderType string, price float64,
 	qty float64, timeInForce string, takeProfit float64, stopLoss float64, reduceOnly bool,
 	closeOnTrigger bool, orderLinkID string, symbol string) (query string, resp []byte, result Order, err error) {
-	var cResult OrderResponse
-	params := map[string]interface{}{}
-	params["side"] = side
-	params["symbol"] = symbol
-	params["order_type"] = orderType
-	params["qty"] = qty
-	if price > 0 {
-		params["price"] = price
-	}
-	params["time_in_force"] = timeInForce
-	if takeProfit > 0 {
-		params["take_profit"] = takeProfit
-	}
-	if stopLoss > 0 {
-		params["stop_loss"] = stopLoss
-	}
-	params["reduce_only"] = reduceOnly
-	params["close_on_trigger"] = closeOnTrigger
-	if orderLinkID != "" {
-		params["order_link_id"] = orderLinkID
-	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/order/create", params, &cResult)
-	if err != nil {
-		return
-	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	return b.LinearPlaceOrder(LinearCreateOrderRequest{
+		Symbol:         symbol,
+		Side:           Side(side),
+		OrderType:      OrderType(orderType),
+		Qty:            qty,
+		Price:          price,
+		TimeInForce:    TimeInForce(timeInForce),
+		TakeProfit:     takeProfit,
+		StopLoss:       stopLoss,
+		ReduceOnly:     reduceOnly,
+		CloseOnTrigger: closeOnTrigger,
+		OrderLinkID:    orderLinkID,
+	})
+}
+
+// LinearPlaceOrder creates a USDT perpetual order, checked first against the
+// SymbolInfo filters when SetOrderValidation is enabled
+// A failed call carrying OrderLinkID is retried according to the retry policy,
+// the order is looked up with LinearGetActiveOrder before being resent.
+func (b *ByBit) LinearPlaceOrder(req LinearCreateOrderRequest) (query string, resp []byte, result Order, err error) {
+	if err = b.preflightOrder(req.Symbol, &req.Qty, &req.Price, &req.TakeProfit, &req.StopLoss); err != nil {
 		return
 	}
 	// {"ret_code":0,"ret_msg":"OK","ext_code":"","ext_info":"","result":{"order_id":"6f771a91-0f4e-4c01-973d-b58e6390ece0","user_id":443679,"symbol":"BTCUSDT","side":"Buy","order_type":"Limit","price":37927.5,"qty":1,"time_in_force":"GoodTillCancel","order_status":"Created","last_exec_price":0,"cum_exec_qty":0,"cum_exec_value":0,"cum_exec_fee":0,"reduce_only":false,"close_on_trigger":false,"order_link_id":"","created_time":"2022-01-25T02:06:25Z","updated_time":"2022-01-25T02:06:25Z","take_profit":0,"stop_loss":0,"tp_trigger_by":"UNKNOWN","sl_trigger_by":"UNKNOWN","position_idx":1},"time_now":"1643076385.967696","rate_limit_status":99,"rate_limit_reset_ms":1643076385963,"rate_limit":100}
-	result = cResult.Result
-	return
+	return b.placeOrder("private/linear/order/create", req.params(), req.Symbol, req.OrderLinkID, true)
 }
 
 // LinearReplaceOrder Replace order can modify/amend your active orders.
@@ -167,14 +147,10 @@ func (b *ByBit) LinearReplaceOrder(symbol string, orderID string, orderLinkId st
 	if slTriggerBy != "" {
 		params["sl_trigger_by"] = slTriggerBy
 	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/order/replace", params, &cResult)
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/order/replace", params, &cResult)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	orderId = cResult.Result.OrderId
 	return
 }
@@ -183,26 +159,8 @@ func (b *ByBit) LinearReplaceOrder(symbol string, orderID string, orderLinkId st
 // orderID: Order ID. Required if not passing order_link_id
 // orderLinkId: Unique user-set order ID. Required if not passing order_id
 func (b *ByBit) LinearCancelOrder(orderID string, orderLinkId string, symbol string) (query string, resp []byte, result Order, err error) {
-	var cResult OrderResponse
-	params := map[string]interface{}{}
-	params["symbol"] = symbol
-	if orderID != "" {
-		params["order_id"] = orderID
-	}
-	if orderLinkId != "" {
-		params["order_link_id"] = orderLinkId
-	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/order/cancel", params, &cResult)
-	if err != nil {
-		return
-	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	// {"ret_code":0,"ret_msg":"OK","ext_code":"","ext_info":"","result":{"order_id":"d328974d-bfe8-484f-a0e9-30159bc78aaf"},"time_now":"1643077335.069762","rate_limit_status":99,"rate_limit_reset_ms":1643077335056,"rate_limit":100}
-	result = cResult.Result
-	return
+	return b.cancelOrder("private/linear/order/cancel", symbol, orderID, orderLinkId)
 }
 
 // LinearCancelAllOrder
@@ -210,22 +168,18 @@ func (b *ByBit) LinearCancelAllOrder(symbol string) (query string, resp []byte,
 	var cResult ResultStringArrayResponse
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/order/cancel-all", params, &cResult)
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/order/cancel-all", params, &cResult)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 
 	result = cResult.Result
 	return
 }
 
 // LinearGetStopOrders
-func (b *ByBit) LinearGetStopOrders(symbol string, stopOrderStatus string, limit int, page int) (query string, resp []byte, result StopOrderListResponseResult, err error) {
-	var cResult StopOrderListResponse
+func (b *ByBit) LinearGetStopOrders(symbol string, stopOrderStatus string, limit int, page int) (query string, resp []byte, result StopOrderListResponseResultPaginated, err error) {
+	var cResult StopOrderListResponsePaginated
 	if limit == 0 {
 		limit = 20
 	}
@@ -240,10 +194,6 @@ func (b *ByBit) LinearGetStopOrders(symbol string, stopOrderStatus string, limit
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult.Result
 	return
 }
@@ -257,10 +207,6 @@ func (b *ByBit) LinearGetActiveStopOrders(symbol string) (query string, resp []b
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult
 	return
 }
@@ -268,29 +214,29 @@ func (b *ByBit) LinearGetActiveStopOrders(symbol string) (query string, resp []b
 // CreateStopOrder
 func (b *ByBit) LinearCreateStopOrder(side string, orderType string, price float64, basePrice float64, stopPx float64,
 	qty float64, triggerBy string, timeInForce string, closeOnTrigger bool, symbol string, reduceOnly bool) (query string, resp []byte, result StopOrder, err error) {
+	return b.LinearPlaceStopOrder(LinearStopOrderRequest{
+		Symbol:         symbol,
+		Side:           Side(side),
+		OrderType:      OrderType(orderType),
+		Qty:            qty,
+		Price:          price,
+		BasePrice:      basePrice,
+		StopPx:         stopPx,
+		TriggerBy:      TriggerBy(triggerBy),
+		TimeInForce:    TimeInForce(timeInForce),
+		ReduceOnly:     reduceOnly,
+		CloseOnTrigger: closeOnTrigger,
+	})
+}
+
+// LinearPlaceStopOrder creates a conditional order
+func (b *ByBit) LinearPlaceStopOrder(req LinearStopOrderRequest) (query string, resp []byte, result StopOrder, err error) {
 	var cResult StopOrderResponse
-	params := map[string]interface{}{}
-	params["side"] = side
-	params["symbol"] = symbol
-	params["order_type"] = orderType
-	params["qty"] = qty
-	if price > 0 {
-		params["price"] = price
-	}
-	params["base_price"] = basePrice
-	params["stop_px"] = stopPx
-	params["time_in_force"] = timeInForce
-	params["close_on_trigger"] = closeOnTrigger
-	params["reduce_only"] = reduceOnly
-	if triggerBy != "" {
-		params["trigger_by"] = triggerBy
-	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/stop-order/create", params, &cResult)
-	if err != nil {
+	if err = b.preflightOrder(req.Symbol, &req.Qty, &req.Price, &req.StopPx, &req.TakeProfit, &req.StopLoss); err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/stop-order/create", req.params(), &cResult)
+	if err != nil {
 		return
 	}
 	result = cResult.Result
@@ -299,25 +245,24 @@ func (b *ByBit) LinearCreateStopOrder(side string, orderType string, price float
 
 // ReplaceStopOrder
 func (b *ByBit) LinearReplaceStopOrder(symbol string, orderID string, qty float64, price float64, triggerPrice float64) (query string, resp []byte, result StopOrder, err error) {
+	return b.LinearAmendStopOrder(ReplaceStopOrderRequest{
+		Symbol:       symbol,
+		StopOrderID:  orderID,
+		Qty:          qty,
+		Price:        price,
+		TriggerPrice: triggerPrice,
+	})
+}
+
+// LinearAmendStopOrder amends an untriggered conditional order, by stop_order_id or order_link_id
+func (b *ByBit) LinearAmendStopOrder(req ReplaceStopOrderRequest) (query string, resp []byte, result StopOrder, err error) {
 	var cResult StopOrderResponse
-	params := map[string]interface{}{}
-	params["stop_order_id"] = orderID
-	params["symbol"] = symbol
-	if qty > 0 {
-		params["p_r_qty"] = qty
-	}
-	if price > 0 {
-		params["p_r_price"] = price
-	}
-	if triggerPrice > 0 {
-		params["p_r_trigger_price"] = triggerPrice
-	}
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/stop-order/replace", params, &cResult)
+	params, err := req.params(true)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/stop-order/replace", params, &cResult)
+	if err != nil {
 		return
 	}
 	result.StopOrderId = cResult.Result.StopOrderId
@@ -326,20 +271,12 @@ func (b *ByBit) LinearReplaceStopOrder(symbol string, orderID string, qty float6
 
 // CancelStopOrder
 func (b *ByBit) LinearCancelStopOrder(orderID string, symbol string) (query string, resp []byte, result StopOrder, err error) {
-	var cResult StopOrderResponse
-	params := map[string]interface{}{}
-	params["symbol"] = symbol
-	params["stop_order_id"] = orderID
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/stop-order/cancel", params, &cResult)
-	if err != nil {
-		return
-	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
-	result.StopOrderId = cResult.Result.StopOrderId
-	return
+	return b.cancelStopOrder("private/linear/stop-order/cancel", symbol, orderID, "")
+}
+
+// LinearCancelStopOrderByLinkID cancels an untriggered conditional order by its order_link_id
+func (b *ByBit) LinearCancelStopOrderByLinkID(orderLinkID string, symbol string) (query string, resp []byte, result StopOrder, err error) {
+	return b.cancelStopOrder("private/linear/stop-order/cancel", symbol, "", orderLinkID)
 }
 
 // CancelAllStopOrders
@@ -347,14 +284,10 @@ func (b *ByBit) LinearCancelAllStopOrders(symbol string) (query string, resp []b
 	var cResult ResultStringArrayResponse
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
-	query, resp, err = b.SignedRequest(http.MethodPost, "private/linear/stop-order/cancel-all", params, &cResult)
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/stop-order/cancel-all", params, &cResult)
 	if err != nil {
 		return
 	}
-	if cResult.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", cResult.RetMsg, string(resp))
-		return
-	}
 	result = cResult.Result
 	return
 }
@@ -367,10 +300,6 @@ func (b *ByBit) LinearGetPositions() (query string, resp []byte, result []Linear
 	if err != nil {
 		return
 	}
-	if r.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", r.RetMsg, string(resp))
-		return
-	}
 	result = r.Result
 	return
 }
@@ -386,8 +315,191 @@ func (b *ByBit) LinearGetPosition(symbol string) (query string, resp []byte, res
 	if err != nil {
 		return
 	}
-	if r.RetCode != 0 {
-		err = fmt.Errorf("%v body: [%v]", r.RetMsg, string(resp))
+	result = r.Result
+	return
+}
+
+// LinearSetLeverage sets the leverage of both sides, buyLeverage must equal sellLeverage in cross margin mode
+func (b *ByBit) LinearSetLeverage(symbol string, buyLeverage float64, sellLeverage float64) (query string, resp []byte, err error) {
+	var r BaseResult
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	params["buy_leverage"] = buyLeverage
+	params["sell_leverage"] = sellLeverage
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/position/set-leverage", params, &r)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// LinearAddMargin adds margin to, or with a negative margin removes margin from, an isolated position
+func (b *ByBit) LinearAddMargin(symbol string, side Side, positionIdx PositionIdx, margin float64) (query string, resp []byte, result LinearAddMarginResult, err error) {
+	var r LinearAddMarginResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	params["side"] = side
+	params["margin"] = margin
+	if positionIdx != PositionIdxOneWay {
+		params["position_idx"] = positionIdx
+	}
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/position/add-margin", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// LinearSetTradingStop sets the take profit, stop loss or trailing stop of a position
+func (b *ByBit) LinearSetTradingStop(req TradingStopRequest) (query string, resp []byte, err error) {
+	var r BaseResult
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/position/trading-stop", req.params(true), &r)
+	return
+}
+
+// LinearSwitchIsolated switches a position between cross and isolated margin
+func (b *ByBit) LinearSwitchIsolated(req SwitchIsolatedRequest) (query string, resp []byte, err error) {
+	var r BaseResult
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/position/switch-isolated", req.params(), &r)
+	return
+}
+
+// LinearSwitchPositionMode switches symbol between one-way and hedge mode
+func (b *ByBit) LinearSwitchPositionMode(symbol string, mode PositionMode) (query string, resp []byte, err error) {
+	var r BaseResult
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	params["mode"] = mode
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/position/switch-mode", params, &r)
+	return
+}
+
+// LinearSetAutoAddMargin turns the automatic margin replenishment of a position on or off
+func (b *ByBit) LinearSetAutoAddMargin(symbol string, side Side, positionIdx PositionIdx, autoAddMargin bool) (query string, resp []byte, err error) {
+	var r BaseResult
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	params["side"] = side
+	params["auto_add_margin"] = autoAddMargin
+	if positionIdx != PositionIdxOneWay {
+		params["position_idx"] = positionIdx
+	}
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/position/set-auto-add-margin", params, &r)
+	return
+}
+
+// LinearGetRiskLimits returns the risk limits of symbol
+func (b *ByBit) LinearGetRiskLimits(symbol string) (query string, resp []byte, result []RiskLimit, err error) {
+	var r RiskLimitArrayResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	query, resp, err = b.PublicRequest(http.MethodGet, "public/linear/risk-limit", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// LinearSetRiskLimit sets the risk limit of a position, riskID from LinearGetRiskLimits
+func (b *ByBit) LinearSetRiskLimit(symbol string, side Side, positionIdx PositionIdx, riskID int) (query string, resp []byte, result SetRiskLimitResult, err error) {
+	var r SetRiskLimitResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	params["side"] = side
+	params["risk_id"] = riskID
+	if positionIdx != PositionIdxOneWay {
+		params["position_idx"] = positionIdx
+	}
+	query, resp, err = b.SignedJSONRequest(http.MethodPost, "private/linear/position/set-risk", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// LinearGetExecutions returns the fills of symbol, newest first
+// startTime, endTime: optional, milliseconds
+// execType: optional, Trade/AdlTrade/Funding/BustTrade
+func (b *ByBit) LinearGetExecutions(symbol string, startTime int64, endTime int64, execType string, page int, limit int) (query string, resp []byte, result []Execution, err error) {
+	var r LinearExecutionListResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	if startTime > 0 {
+		params["start_time"] = startTime
+	}
+	if endTime > 0 {
+		params["end_time"] = endTime
+	}
+	if execType != "" {
+		params["exec_type"] = execType
+	}
+	if page > 0 {
+		params["page"] = page
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	query, resp, err = b.SignedRequest(http.MethodGet, "private/linear/trade/execution/list", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result.Data
+	for i := range result {
+		result[i].normalize()
+	}
+	return
+}
+
+// LinearGetClosedPnl returns the closed PnL records of symbol, newest first
+// startTime, endTime: optional, seconds
+// execType: optional, Trade/AdlTrade/Funding/BustTrade
+func (b *ByBit) LinearGetClosedPnl(symbol string, startTime int64, endTime int64, execType string, page int, limit int) (query string, resp []byte, result []ClosedPnl, err error) {
+	var r ClosedPnlResponse
+	params := closedPnlParams(symbol, startTime, endTime, execType, page, limit)
+	query, resp, err = b.SignedRequest(http.MethodGet, "private/linear/trade/closed-pnl/list", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result.Data
+	return
+}
+
+// LinearGetPrevFundingRate returns the last settled funding rate of symbol
+func (b *ByBit) LinearGetPrevFundingRate(symbol string) (query string, resp []byte, result FundingRate, err error) {
+	var r FundingRateResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	query, resp, err = b.PublicRequest(http.MethodGet, "public/linear/funding/prev-funding-rate", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// LinearGetMyLastFundingFee returns the funding fee of the last settlement of symbol
+func (b *ByBit) LinearGetMyLastFundingFee(symbol string) (query string, resp []byte, result FundingFee, err error) {
+	var r FundingFeeResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	query, resp, err = b.SignedRequest(http.MethodGet, "private/linear/funding/prev-funding", params, &r)
+	if err != nil {
+		return
+	}
+	result = r.Result
+	return
+}
+
+// LinearGetPredictedFunding returns the funding rate and fee of the next settlement of symbol
+func (b *ByBit) LinearGetPredictedFunding(symbol string) (query string, resp []byte, result PredictedFunding, err error) {
+	var r PredictedFundingResponse
+	params := map[string]interface{}{}
+	params["symbol"] = symbol
+	query, resp, err = b.SignedRequest(http.MethodGet, "private/linear/funding/predicted-funding", params, &r)
+	if err != nil {
 		return
 	}
 	result = r.Result
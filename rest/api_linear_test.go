@@ -84,7 +84,7 @@ func TestByBit_LinearGetPositions(t *testing.T) {
 	}
 	// t.Logf("%#v", ret)
 	for _, v := range ret {
-		if !v.IsValid || v.Data.Size == 0 {
+		if !v.IsValid || v.Data.Size.IsZero() {
 			continue
 		}
 		t.Logf("%#v", v)
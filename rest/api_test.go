@@ -1,9 +1,13 @@
 package rest
 
 import (
+	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -46,6 +50,173 @@ func newByBit() *ByBit {
 	return b
 }
 
+// newTestByBit returns a client pointed at an in-process server running handler
+func newTestByBit(t *testing.T, handler http.HandlerFunc) *ByBit {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return New(nil, ts.URL+"/", "testKey", "testSecret", false)
+}
+
+// blockingHandler holds every request open until the client goes away or the test ends
+func blockingHandler(t *testing.T) http.HandlerFunc {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}
+}
+
+func TestByBit_WithContextCancel(t *testing.T) {
+	b := newTestByBit(t, blockingHandler(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, _, _, err := b.WithContext(ctx).LinearGetPositions()
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+func TestByBit_WithContextDeadline(t *testing.T) {
+	b := newTestByBit(t, blockingHandler(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, _, err := b.WithContext(ctx).GetServerTime()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+}
+
+func TestByBit_WithContextIsolated(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{},"time_now":"1643076385.967696"}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = b.WithContext(ctx)
+
+	_, _, timeNow, err := b.GetServerTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1643076385967), timeNow)
+}
+
+// signing vectors from https://bybit-exchange.github.io/docs/inverse/#t-constructingtherequest
+const (
+	vectorApiKey    = "B2Rou0PLPpGqcU0Vu2"
+	vectorSecretKey = "t7T0YlFnYXk0Fx3JswQsDrViLg1Gh3DUU5Mr"
+	vectorTimestamp = 1542434791000
+)
+
+func newVectorByBit(t *testing.T, handler http.HandlerFunc) *ByBit {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	b := New(nil, ts.URL+"/", vectorApiKey, vectorSecretKey, false)
+	b.clock.now = func() time.Time {
+		return time.Unix(0, vectorTimestamp*int64(time.Millisecond))
+	}
+	return b
+}
+
+func vectorParams() map[string]interface{} {
+	return map[string]interface{}{
+		"leverage": 100,
+		"symbol":   "BTCUSD",
+	}
+}
+
+func TestByBit_SignedQuery(t *testing.T) {
+	var rawQuery string
+	b := newVectorByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":null}`))
+	})
+
+	var ret BaseResult
+	_, _, err := b.SignedRequest(http.MethodPost, "user/leverage/save", vectorParams(), &ret)
+	assert.Nil(t, err)
+	assert.Equal(t, "api_key=B2Rou0PLPpGqcU0Vu2&leverage=100&symbol=BTCUSD&timestamp=1542434791000"+
+		"&sign=670e3e4aa32b243f2dedf1dafcec2fd17a440e71b05681550416507de591d908", rawQuery)
+
+	b.SetRecvWindow(5000)
+	_, _, err = b.SignedRequest(http.MethodPost, "user/leverage/save", vectorParams(), &ret)
+	assert.Nil(t, err)
+	assert.Equal(t, "api_key=B2Rou0PLPpGqcU0Vu2&leverage=100&recv_window=5000&symbol=BTCUSD&timestamp=1542434791000"+
+		"&sign=00a55cf3dc5c8e64cd0f9849f4073d8374010b209b361d14b2e695a2ca65bef3", rawQuery)
+
+	_, _, err = b.WithRecvWindow(1000).SignedRequest(http.MethodPost, "user/leverage/save", vectorParams(), &ret)
+	assert.Nil(t, err)
+	assert.Equal(t, "api_key=B2Rou0PLPpGqcU0Vu2&leverage=100&recv_window=1000&symbol=BTCUSD&timestamp=1542434791000"+
+		"&sign=10e8f0c9c4b2cb948643a142860f7e3d03336eb1e175042cf48667bf61dbbc0c", rawQuery)
+
+	// the override does not leak into the client default
+	_, _, err = b.SignedRequest(http.MethodPost, "user/leverage/save", vectorParams(), &ret)
+	assert.Nil(t, err)
+	assert.Contains(t, rawQuery, "recv_window=5000&")
+}
+
+func TestByBit_SignedJSONBody(t *testing.T) {
+	var rawQuery, contentType string
+	var body map[string]interface{}
+	b := newVectorByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		contentType = r.Header.Get("Content-Type")
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":null}`))
+	})
+
+	_, _, err := b.SetLeverage(100, "BTCUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, "", rawQuery)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, map[string]interface{}{
+		"api_key":   vectorApiKey,
+		"leverage":  "100",
+		"symbol":    "BTCUSD",
+		"timestamp": float64(vectorTimestamp),
+		"sign":      "670e3e4aa32b243f2dedf1dafcec2fd17a440e71b05681550416507de591d908",
+	}, body)
+
+	// GETs stay in the query string
+	var ret BaseResult
+	_, _, err = b.SignedJSONRequest(http.MethodGet, "v2/private/position/list", vectorParams(), &ret)
+	assert.Nil(t, err)
+	assert.Contains(t, rawQuery, "&sign=670e3e4aa32b243f2dedf1dafcec2fd17a440e71b05681550416507de591d908")
+	assert.Nil(t, body)
+}
+
+func TestByBit_SignedJSONBodyFloats(t *testing.T) {
+	var body string
+	b := newVectorByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":null}`))
+	})
+
+	var ret BaseResult
+	params := map[string]interface{}{"qty": 0.0000001, "price": 1e21}
+	_, _, err := b.SignedJSONRequest(http.MethodPost, "private/linear/order/create", params, &ret)
+	assert.Nil(t, err)
+	assert.Contains(t, body, `"qty":0.0000001`)
+	assert.Contains(t, body, `"price":1000000000000000000000`)
+}
+
+func TestFormatParam(t *testing.T) {
+	assert.Equal(t, "3500000", formatParam(3500000.0))
+	assert.Equal(t, "0.00001", formatParam(0.00001))
+	assert.Equal(t, "35000.5", formatParam(35000.5))
+	assert.Equal(t, "10", formatParam(10))
+	assert.Equal(t, "true", formatParam(true))
+}
+
 func TestByBit_GetServerTime(t *testing.T) {
 	b := newByBit()
 	_, _, timeNow, err := b.GetServerTime()
@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"sync"
+)
+
+// defaultBatchConcurrency is the number of requests of a batch in flight at once
+const defaultBatchConcurrency = 5
+
+// BatchResult is the outcome of one item of a batch, Err is nil on success
+type BatchResult struct {
+	Order Order
+	Err   error
+}
+
+// ReplaceOrderRequest amends an active order identified by OrderID or OrderLinkID.
+// Zero qty and prices are left unchanged.
+type ReplaceOrderRequest struct {
+	Symbol      string    `json:"symbol"`
+	OrderID     string    `json:"order_id"`
+	OrderLinkID string    `json:"order_link_id"`
+	Qty         float64   `json:"p_r_qty"` // whole contracts for inverse orders
+	Price       float64   `json:"p_r_price"`
+	TakeProfit  float64   `json:"take_profit"`   // linear only
+	StopLoss    float64   `json:"stop_loss"`     // linear only
+	TpTriggerBy TriggerBy `json:"tp_trigger_by"` // linear only
+	SlTriggerBy TriggerBy `json:"sl_trigger_by"` // linear only
+}
+
+// CancelOrderRequest cancels an active order identified by OrderID or OrderLinkID
+type CancelOrderRequest struct {
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"order_id"`
+	OrderLinkID string `json:"order_link_id"`
+}
+
+// SetBatchConcurrency sets the number of requests of a batch sent at once,
+// 0 restores the default of 5. Every request also waits for the rate limiter.
+func (b *ByBit) SetBatchConcurrency(n int) {
+	b.batchConcurrency = n
+}
+
+// PlaceOrders creates the inverse orders reqs concurrently, see SetBatchConcurrency.
+// The results are in the order of reqs, a failed order does not stop the others.
+func (b *ByBit) PlaceOrders(reqs []CreateOrderRequest) []BatchResult {
+	return b.batch(len(reqs), func(i int) (order Order, err error) {
+		_, _, order, err = b.PlaceOrder(reqs[i])
+		return
+	})
+}
+
+// LinearPlaceOrders creates the USDT perpetual orders reqs concurrently, see PlaceOrders
+func (b *ByBit) LinearPlaceOrders(reqs []LinearCreateOrderRequest) []BatchResult {
+	return b.batch(len(reqs), func(i int) (order Order, err error) {
+		_, _, order, err = b.LinearPlaceOrder(reqs[i])
+		return
+	})
+}
+
+// ReplaceOrders amends the inverse orders reqs concurrently, see PlaceOrders.
+// Only OrderId is set in the result orders.
+func (b *ByBit) ReplaceOrders(reqs []ReplaceOrderRequest) []BatchResult {
+	return b.batch(len(reqs), func(i int) (order Order, err error) {
+		r := reqs[i]
+		qty, err := wholeNumber(r.Qty, "qty")
+		if err != nil {
+			return
+		}
+		_, _, order, err = b.replaceOrder(r.Symbol, r.OrderID, r.OrderLinkID, qty, r.Price)
+		return
+	})
+}
+
+// LinearReplaceOrders amends the USDT perpetual orders reqs concurrently, see ReplaceOrders
+func (b *ByBit) LinearReplaceOrders(reqs []ReplaceOrderRequest) []BatchResult {
+	return b.batch(len(reqs), func(i int) (order Order, err error) {
+		r := reqs[i]
+		_, _, order.OrderId, err = b.LinearReplaceOrder(r.Symbol, r.OrderID, r.OrderLinkID, r.Qty, r.Price,
+			r.TakeProfit, r.StopLoss, string(r.TpTriggerBy), string(r.SlTriggerBy))
+		return
+	})
+}
+
+// CancelOrders cancels the inverse orders reqs concurrently, see PlaceOrders
+func (b *ByBit) CancelOrders(reqs []CancelOrderRequest) []BatchResult {
+	return b.batch(len(reqs), func(i int) (order Order, err error) {
+		_, _, order, err = b.cancelOrder("v2/private/order/cancel", reqs[i].Symbol, reqs[i].OrderID, reqs[i].OrderLinkID)
+		return
+	})
+}
+
+// LinearCancelOrders cancels the USDT perpetual orders reqs concurrently, see PlaceOrders
+func (b *ByBit) LinearCancelOrders(reqs []CancelOrderRequest) []BatchResult {
+	return b.batch(len(reqs), func(i int) (order Order, err error) {
+		_, _, order, err = b.cancelOrder("private/linear/order/cancel", reqs[i].Symbol, reqs[i].OrderID, reqs[i].OrderLinkID)
+		return
+	})
+}
+
+// batch runs do for the n items of a batch on at most batchConcurrency
+// goroutines and collects the results by item
+func (b *ByBit) batch(n int, do func(i int) (Order, error)) []BatchResult {
+	results := make([]BatchResult, n)
+	workers := b.batchConcurrency
+	if workers <= 0 {
+		workers = defaultBatchConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+
+	items := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				results[i].Order, results[i].Err = do(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+	return results
+}
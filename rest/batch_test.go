@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByBit_LinearPlaceOrdersBatch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["order_link_id"] == "q3" {
+			w.Write([]byte(`{"ret_code":130021,"ret_msg":"order cost not available","result":null}`))
+			return
+		}
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"id-` + body["order_link_id"].(string) + `"}}`))
+	})
+	b.SetBatchConcurrency(2)
+
+	var reqs []LinearCreateOrderRequest
+	for _, id := range []string{"q0", "q1", "q2", "q3", "q4"} {
+		reqs = append(reqs, LinearCreateOrderRequest{Symbol: "BTCUSDT", Side: SideBuy, OrderType: OrderTypeLimit, Qty: 0.01, Price: 30000, OrderLinkID: id})
+	}
+	results := b.LinearPlaceOrders(reqs)
+	assert.Len(t, results, 5)
+	for i, r := range results {
+		if i == 3 {
+			var apiErr *APIError
+			assert.True(t, errors.As(r.Err, &apiErr))
+			continue
+		}
+		assert.Nil(t, r.Err)
+		assert.Equal(t, "id-"+reqs[i].OrderLinkID, r.Order.OrderId)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func TestByBit_ReplaceOrders(t *testing.T) {
+	var bodies sync.Map
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		id, _ := body["order_id"].(string)
+		if id == "" {
+			id = body["order_link_id"].(string)
+		}
+		bodies.Store(id, body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"` + id + `"}}`))
+	})
+
+	results := b.ReplaceOrders([]ReplaceOrderRequest{
+		{Symbol: "BTCUSD", OrderID: "a", Qty: 10},
+		{Symbol: "BTCUSD", OrderID: "b", Qty: 10.5},
+		{Symbol: "BTCUSD", OrderLinkID: "c", Price: 35000.5},
+	})
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "a", results[0].Order.OrderId)
+	assert.True(t, errors.Is(results[1].Err, ErrInvalidOrder))
+	assert.Nil(t, results[2].Err)
+	body, _ := bodies.Load("c")
+	assert.Equal(t, map[string]interface{}{
+		"order_link_id": "c",
+		"symbol":        "BTCUSD",
+		"p_r_price":     35000.5,
+	}, withoutAuth(body.(map[string]interface{})))
+}
+
+func TestByBit_CancelOrders(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"` + body["order_id"].(string) + `"}}`))
+	})
+
+	results := b.CancelOrders([]CancelOrderRequest{{Symbol: "BTCUSD", OrderID: "x"}, {Symbol: "BTCUSD", OrderID: "y"}})
+	assert.Equal(t, "x", results[0].Order.OrderId)
+	assert.Equal(t, "y", results[1].Order.OrderId)
+	assert.Empty(t, b.LinearCancelOrders(nil))
+}
+
+// withoutAuth drops the signature params of a request body
+func withoutAuth(body map[string]interface{}) map[string]interface{} {
+	for _, k := range []string{"api_key", "timestamp", "recv_window", "sign"} {
+		delete(body, k)
+	}
+	return body
+}
@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// serverClock keeps the estimated offset between the exchange clock and the local clock
+type serverClock struct {
+	mu     sync.RWMutex
+	offset time.Duration // server time - local time
+	errEst time.Duration // half of the round trip of the best sample
+	synced time.Time
+	resync chan struct{} // non-nil while a sync loop is running
+	now    func() time.Time
+}
+
+func newServerClock() *serverClock {
+	return &serverClock{
+		now: time.Now,
+	}
+}
+
+// timestamp returns the estimated server time in milliseconds
+func (c *serverClock) timestamp() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.now().Add(c.offset).UnixNano() / 1e6
+}
+
+func (c *serverClock) set(offset time.Duration, errEst time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.offset = offset
+	c.errEst = errEst
+	c.synced = time.Now()
+}
+
+// requestResync asks a running sync loop to sync as soon as possible
+func (c *serverClock) requestResync() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.resync == nil {
+		return
+	}
+	select {
+	case c.resync <- struct{}{}:
+	default:
+	}
+}
+
+// ServerTimeOffset returns the estimated difference between the exchange clock and
+// the local clock, its estimated error and when it was last synced
+func (b *ByBit) ServerTimeOffset() (offset time.Duration, errEst time.Duration, syncedAt time.Time) {
+	b.clock.mu.RLock()
+	defer b.clock.mu.RUnlock()
+
+	return b.clock.offset, b.clock.errEst, b.clock.synced
+}
+
+// SyncServerTime samples the exchange clock samples times and keeps the sample with
+// the shortest round trip, assuming the server read its clock at the midpoint of it.
+func (b *ByBit) SyncServerTime(samples int) (offset time.Duration, errEst time.Duration, err error) {
+	if samples < 1 {
+		samples = 1
+	}
+	var best time.Duration = -1
+	for i := 0; i < samples; i++ {
+		sent := time.Now()
+		var serverTime time.Time
+		serverTime, err = b.serverTime()
+		if err != nil {
+			return
+		}
+		received := time.Now()
+
+		rtt := received.Sub(sent)
+		if best < 0 || rtt < best {
+			best = rtt
+			offset = serverTime.Sub(sent.Add(rtt / 2))
+		}
+	}
+	errEst = best / 2
+	b.clock.set(offset, errEst)
+	if b.debugMode {
+		log.Printf("SyncServerTime: offset %v error %v", offset, errEst)
+	}
+	return
+}
+
+// StartServerTimeSync syncs the server time once and keeps it in sync in the
+// background every interval until ctx is done. A sync also happens as soon as
+// a signed request is rejected for its timestamp.
+func (b *ByBit) StartServerTimeSync(ctx context.Context, interval time.Duration, samples int) error {
+	if interval <= 0 {
+		return errors.New("bybit: server time sync interval must be positive")
+	}
+	resync := make(chan struct{}, 1)
+	b.clock.mu.Lock()
+	if b.clock.resync != nil {
+		b.clock.mu.Unlock()
+		return errors.New("bybit: server time sync already started")
+	}
+	b.clock.resync = resync
+	b.clock.mu.Unlock()
+
+	stop := func() {
+		b.clock.mu.Lock()
+		b.clock.resync = nil
+		b.clock.mu.Unlock()
+	}
+	if _, _, err := b.WithContext(ctx).SyncServerTime(samples); err != nil {
+		stop()
+		return err
+	}
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		defer stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			case <-resync:
+			}
+			if _, _, err := b.WithContext(ctx).SyncServerTime(samples); err != nil && ctx.Err() == nil {
+				log.Printf("SyncServerTime error: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// serverTime returns the exchange clock with microsecond precision
+func (b *ByBit) serverTime() (t time.Time, err error) {
+	var ret BaseResult
+	_, _, err = b.PublicRequest(http.MethodGet, "v2/public/time", map[string]interface{}{}, &ret)
+	if err != nil {
+		return
+	}
+	return parseTimeNow(ret.TimeNow)
+}
+
+// parseTimeNow parses time_now, seconds since epoch like "1582011750.433202"
+func parseTimeNow(s string) (t time.Time, err error) {
+	var f float64
+	f, err = strconv.ParseFloat(s, 64)
+	if err != nil {
+		return
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)), nil
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// timeNowBody answers like v2/public/time with a clock running ahead by skew
+func timeNowBody(skew time.Duration) string {
+	now := time.Now().Add(skew)
+	return fmt.Sprintf(`{"ret_code":0,"ret_msg":"OK","result":{},"time_now":"%d.%06d"}`, now.Unix(), now.Nanosecond()/1000)
+}
+
+func TestByBit_SyncServerTime(t *testing.T) {
+	var samples int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&samples, 1)
+		w.Write([]byte(timeNowBody(5 * time.Second)))
+	})
+
+	offset, errEst, err := b.SyncServerTime(4)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&samples))
+	assert.InDelta(t, float64(5*time.Second), float64(offset), float64(errEst+5*time.Millisecond))
+
+	got, gotErr, syncedAt := b.ServerTimeOffset()
+	assert.Equal(t, offset, got)
+	assert.Equal(t, errEst, gotErr)
+	assert.False(t, syncedAt.IsZero())
+	assert.InDelta(t, time.Now().Add(5*time.Second).UnixNano()/1e6, b.clock.timestamp(), 50)
+}
+
+func TestByBit_ServerTimeResyncOnTimestampError(t *testing.T) {
+	var samples int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/public/time" {
+			atomic.AddInt32(&samples, 1)
+			w.Write([]byte(timeNowBody(-3 * time.Second)))
+			return
+		}
+		w.Write([]byte(`{"ret_code":10002,"ret_msg":"invalid request, please check your timestamp and recv_window param","result":null}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Nil(t, b.StartServerTimeSync(ctx, time.Hour, 1))
+	assert.NotNil(t, b.StartServerTimeSync(ctx, time.Hour, 1))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&samples))
+
+	_, _, _, err := b.LinearGetPositions()
+	assert.True(t, IsTimestampExpired(err))
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&samples) == 2
+	}, time.Second, 5*time.Millisecond)
+}
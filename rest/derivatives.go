@@ -0,0 +1,330 @@
+package rest
+
+import (
+	"fmt"
+	"github.com/wilcosheh/bybit-api/decimal"
+	"math"
+	"time"
+)
+
+// Category is the contract family of a symbol
+type Category string
+
+const (
+	CategoryInverse Category = "inverse" // inverse perpetual, e.g. BTCUSD, margined in the base currency
+	CategoryLinear  Category = "linear"  // USDT perpetual, e.g. BTCUSDT
+)
+
+// Category returns the contract family of the symbol, from its quote currency
+func (s SymbolInfo) Category() Category {
+	if s.QuoteCurrency == "USDT" {
+		return CategoryLinear
+	}
+	return CategoryInverse
+}
+
+// SettleCoin returns the margin and settlement currency of the symbol
+func (s SymbolInfo) SettleCoin() string {
+	if s.Category() == CategoryLinear {
+		return s.QuoteCurrency
+	}
+	return s.BaseCurrency
+}
+
+// OrderRequest is an order of either contract family, see Derivatives.PlaceOrder.
+// Inverse quantities are whole contracts.
+type OrderRequest struct {
+	Symbol         string      `json:"symbol"`
+	Side           Side        `json:"side"`
+	OrderType      OrderType   `json:"order_type"`
+	Qty            float64     `json:"qty"`
+	Price          float64     `json:"price"`
+	TimeInForce    TimeInForce `json:"time_in_force"`
+	TakeProfit     float64     `json:"take_profit"`
+	StopLoss       float64     `json:"stop_loss"`
+	TpTriggerBy    TriggerBy   `json:"tp_trigger_by"`
+	SlTriggerBy    TriggerBy   `json:"sl_trigger_by"`
+	ReduceOnly     bool        `json:"reduce_only"`
+	CloseOnTrigger bool        `json:"close_on_trigger"`
+	OrderLinkID    string      `json:"order_link_id"`
+	PositionIdx    PositionIdx `json:"position_idx"` // required in hedge mode, of both USDT and inverse perpetuals
+}
+
+// PositionInfo is a position normalised from Position and LinearPosition
+type PositionInfo struct {
+	Symbol        string          `json:"symbol"`
+	Category      Category        `json:"category"`
+	Side          string          `json:"side"`
+	Size          decimal.Decimal `json:"size"`
+	PositionValue decimal.Decimal `json:"position_value"`
+	EntryPrice    decimal.Decimal `json:"entry_price"`
+	LiqPrice      decimal.Decimal `json:"liq_price"`
+	Leverage      decimal.Decimal `json:"leverage"`
+	TakeProfit    decimal.Decimal `json:"take_profit"`
+	StopLoss      decimal.Decimal `json:"stop_loss"`
+	UnrealisedPnl decimal.Decimal `json:"unrealised_pnl"`
+	RealisedPnl   decimal.Decimal `json:"realised_pnl"`
+	PositionIdx   PositionIdx     `json:"position_idx"`
+}
+
+// Derivatives is the api shared by the inverse and USDT perpetuals, every
+// call is routed by the category of its symbol, see ByBit.Derivatives
+type Derivatives interface {
+	// Category returns the contract family of symbol
+	Category(symbol string) (Category, error)
+	// Klines returns up to limit candles from from
+	Klines(symbol string, interval string, from time.Time, limit int) ([]Candle, error)
+	// KlineRange downloads the candles opening in [start, end), see ByBit.KlineRange
+	KlineRange(symbol string, interval string, start time.Time, end time.Time) ([]Candle, []KlineGap, error)
+	// Balance returns the wallet balance of the settlement coin of symbol
+	Balance(symbol string) (Balance, error)
+	// Positions returns the positions of symbol, both sides in hedge mode
+	Positions(symbol string) ([]PositionInfo, error)
+	// SetLeverage sets the leverage of symbol
+	SetLeverage(symbol string, leverage float64) error
+	// PlaceOrder creates an order
+	PlaceOrder(req OrderRequest) (Order, error)
+	// ReplaceOrder amends the qty and/or price of an active order, 0 leaves them unchanged
+	ReplaceOrder(symbol string, orderID string, qty float64, price float64) (string, error)
+	// CancelOrder cancels an active order
+	CancelOrder(symbol string, orderID string) (Order, error)
+	// CancelAllOrders cancels the active orders of symbol
+	CancelAllOrders(symbol string) error
+	// ActiveOrders returns the active orders of symbol
+	ActiveOrders(symbol string) ([]Order, error)
+}
+
+// Derivatives returns the Derivatives api of b, the symbol categories come
+// from the instrument cache, loaded on first use
+func (b *ByBit) Derivatives() Derivatives {
+	return &derivatives{b: b}
+}
+
+type derivatives struct {
+	b *ByBit
+}
+
+func (d *derivatives) Category(symbol string) (Category, error) {
+	info, err := d.b.instrument(symbol)
+	if err != nil {
+		return "", err
+	}
+	return info.Category(), nil
+}
+
+func (d *derivatives) Klines(symbol string, interval string, from time.Time, limit int) (candles []Candle, err error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return
+	}
+	if category == CategoryLinear {
+		_, _, result, err := d.b.LinearGetKLine(symbol, interval, from.Unix(), limit)
+		for i := range result {
+			candles = append(candles, result[i].Candle())
+		}
+		return candles, err
+	}
+	_, _, result, err := d.b.GetKLine(symbol, interval, from.Unix(), limit)
+	for i := range result {
+		candles = append(candles, result[i].Candle())
+	}
+	return candles, err
+}
+
+func (d *derivatives) KlineRange(symbol string, interval string, start time.Time, end time.Time) ([]Candle, []KlineGap, error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	if category == CategoryLinear {
+		return d.b.LinearKlineRange(symbol, interval, start, end)
+	}
+	return d.b.KlineRange(symbol, interval, start, end)
+}
+
+func (d *derivatives) Balance(symbol string) (balance Balance, err error) {
+	info, err := d.b.instrument(symbol)
+	if err != nil {
+		return
+	}
+	_, _, balance, err = d.b.GetWalletBalance(info.SettleCoin())
+	return
+}
+
+func (d *derivatives) Positions(symbol string) (positions []PositionInfo, err error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return
+	}
+	if category == CategoryLinear {
+		_, _, result, err := d.b.LinearGetPosition(symbol)
+		for _, p := range result {
+			positions = append(positions, PositionInfo{
+				Symbol:        p.Symbol,
+				Category:      CategoryLinear,
+				Side:          p.Side,
+				Size:          p.Size,
+				PositionValue: p.PositionValue,
+				EntryPrice:    p.EntryPrice,
+				LiqPrice:      p.LiqPrice,
+				Leverage:      p.Leverage,
+				TakeProfit:    p.TakeProfit,
+				StopLoss:      p.StopLoss,
+				UnrealisedPnl: p.UnrealisedPnl,
+				RealisedPnl:   p.RealisedPnl,
+				PositionIdx:   PositionIdx(p.PositionIdx),
+			})
+		}
+		return positions, err
+	}
+	_, _, p, err := d.b.GetPosition(symbol)
+	if err != nil {
+		return
+	}
+	positions = append(positions, PositionInfo{
+		Symbol:        p.Symbol,
+		Category:      CategoryInverse,
+		Side:          p.Side,
+		Size:          p.Size,
+		PositionValue: p.PositionValue,
+		EntryPrice:    p.EntryPrice,
+		LiqPrice:      p.LiqPrice,
+		Leverage:      p.Leverage,
+		TakeProfit:    p.TakeProfit,
+		StopLoss:      p.StopLoss,
+		UnrealisedPnl: p.UnrealisedPnl,
+		RealisedPnl:   p.RealisedPnl,
+	})
+	return
+}
+
+func (d *derivatives) SetLeverage(symbol string, leverage float64) (err error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return
+	}
+	if category == CategoryLinear {
+		_, _, err = d.b.LinearSetLeverage(symbol, leverage, leverage)
+		return
+	}
+	lev, err := wholeNumber(leverage, "leverage")
+	if err != nil {
+		return
+	}
+	_, _, err = d.b.SetLeverage(lev, symbol)
+	return
+}
+
+func (d *derivatives) PlaceOrder(req OrderRequest) (order Order, err error) {
+	category, err := d.Category(req.Symbol)
+	if err != nil {
+		return
+	}
+	if category == CategoryLinear {
+		_, _, order, err = d.b.LinearPlaceOrder(LinearCreateOrderRequest{
+			Symbol:         req.Symbol,
+			Side:           req.Side,
+			OrderType:      req.OrderType,
+			Qty:            req.Qty,
+			Price:          req.Price,
+			TimeInForce:    req.TimeInForce,
+			TakeProfit:     req.TakeProfit,
+			StopLoss:       req.StopLoss,
+			TpTriggerBy:    req.TpTriggerBy,
+			SlTriggerBy:    req.SlTriggerBy,
+			ReduceOnly:     req.ReduceOnly,
+			CloseOnTrigger: req.CloseOnTrigger,
+			OrderLinkID:    req.OrderLinkID,
+			PositionIdx:    req.PositionIdx,
+		})
+		return
+	}
+	qty, err := wholeNumber(req.Qty, "qty")
+	if err != nil {
+		return
+	}
+	_, _, order, err = d.b.PlaceOrder(CreateOrderRequest{
+		Symbol:         req.Symbol,
+		Side:           req.Side,
+		OrderType:      req.OrderType,
+		Qty:            qty,
+		Price:          req.Price,
+		TimeInForce:    req.TimeInForce,
+		TakeProfit:     req.TakeProfit,
+		StopLoss:       req.StopLoss,
+		TpTriggerBy:    req.TpTriggerBy,
+		SlTriggerBy:    req.SlTriggerBy,
+		ReduceOnly:     req.ReduceOnly,
+		CloseOnTrigger: req.CloseOnTrigger,
+		OrderLinkID:    req.OrderLinkID,
+		PositionIdx:    req.PositionIdx,
+	})
+	return
+}
+
+func (d *derivatives) ReplaceOrder(symbol string, orderID string, qty float64, price float64) (replacedID string, err error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return
+	}
+	if category == CategoryLinear {
+		_, _, replacedID, err = d.b.LinearReplaceOrder(symbol, orderID, "", qty, price, 0, 0, "", "")
+		return
+	}
+	contracts, err := wholeNumber(qty, "qty")
+	if err != nil {
+		return
+	}
+	_, _, order, err := d.b.ReplaceOrder(symbol, orderID, contracts, price)
+	replacedID = order.OrderId
+	return
+}
+
+func (d *derivatives) CancelOrder(symbol string, orderID string) (order Order, err error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return
+	}
+	if category == CategoryLinear {
+		_, _, order, err = d.b.LinearCancelOrder(orderID, "", symbol)
+		return
+	}
+	_, _, order, err = d.b.CancelOrder(orderID, symbol)
+	return
+}
+
+func (d *derivatives) CancelAllOrders(symbol string) (err error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return
+	}
+	if category == CategoryLinear {
+		_, _, _, err = d.b.LinearCancelAllOrder(symbol)
+		return
+	}
+	_, _, _, err = d.b.CancelAllOrder(symbol)
+	return
+}
+
+func (d *derivatives) ActiveOrders(symbol string) (orders []Order, err error) {
+	category, err := d.Category(symbol)
+	if err != nil {
+		return
+	}
+	var result OrderArrayResponse
+	if category == CategoryLinear {
+		_, _, result, err = d.b.LinearGetActiveOrders(symbol)
+	} else {
+		_, _, result, err = d.b.GetActiveOrders(symbol)
+	}
+	orders = result.Result
+	return
+}
+
+// wholeNumber converts an inverse qty or leverage, which the inverse endpoints take as integers
+func wholeNumber(v float64, name string) (int, error) {
+	if v != math.Trunc(v) {
+		return 0, fmt.Errorf("%w: inverse %v %v is not a whole number", ErrInvalidOrder, name, v)
+	}
+	return int(v), nil
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerivatives_Routing(t *testing.T) {
+	var paths []string
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/v2/public/symbols":
+			w.Write([]byte(testSymbolsBody))
+		case "/v2/private/order/create", "/private/linear/order/create":
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"abc"}}`))
+		case "/private/linear/position/list":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[{"symbol":"BTCUSDT","side":"Buy","size":0.5,"entry_price":35000,"position_idx":1},{"symbol":"BTCUSDT","side":"Sell","size":0,"position_idx":2}]}`))
+		case "/v2/private/position/list":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"symbol":"BTCUSD","side":"Sell","size":100,"entry_price":"35000.5","leverage":"2"}}`))
+		}
+	})
+	d := b.Derivatives()
+
+	category, err := d.Category("BTCUSDT")
+	assert.Nil(t, err)
+	assert.Equal(t, CategoryLinear, category)
+	category, err = d.Category("BTCUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, CategoryInverse, category)
+	_, err = d.Category("NOPE")
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+
+	_, err = d.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: SideBuy, OrderType: OrderTypeMarket, Qty: 0.01})
+	assert.Nil(t, err)
+	assert.Equal(t, "/private/linear/order/create", paths[len(paths)-1])
+	assert.Equal(t, 0.01, body["qty"])
+
+	_, err = d.PlaceOrder(OrderRequest{Symbol: "BTCUSD", Side: SideBuy, OrderType: OrderTypeMarket, Qty: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, "/v2/private/order/create", paths[len(paths)-1])
+	assert.Equal(t, float64(100), body["qty"])
+	assert.NotContains(t, body, "position_idx")
+
+	// hedge mode positions of both families
+	_, err = d.PlaceOrder(OrderRequest{Symbol: "BTCUSDT", Side: SideSell, OrderType: OrderTypeMarket, Qty: 0.01, PositionIdx: PositionIdxHedgeSell})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(2), body["position_idx"])
+	_, err = d.PlaceOrder(OrderRequest{Symbol: "BTCUSD", Side: SideBuy, OrderType: OrderTypeMarket, Qty: 100, PositionIdx: PositionIdxHedgeBuy})
+	assert.Nil(t, err)
+	assert.Equal(t, "/v2/private/order/create", paths[len(paths)-1])
+	assert.Equal(t, float64(1), body["position_idx"])
+
+	n := len(paths)
+	_, err = d.PlaceOrder(OrderRequest{Symbol: "BTCUSD", Side: SideBuy, OrderType: OrderTypeMarket, Qty: 0.5})
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	assert.Len(t, paths, n)
+
+	positions, err := d.Positions("BTCUSDT")
+	assert.Nil(t, err)
+	if assert.Len(t, positions, 2) {
+		assert.Equal(t, "0.5", positions[0].Size.String())
+		assert.Equal(t, PositionIdxHedgeSell, positions[1].PositionIdx)
+	}
+	positions, err = d.Positions("BTCUSD")
+	assert.Nil(t, err)
+	if assert.Len(t, positions, 1) {
+		assert.Equal(t, CategoryInverse, positions[0].Category)
+		assert.Equal(t, "35000.5", positions[0].EntryPrice.String())
+	}
+
+	// the instrument cache is loaded once
+	count := 0
+	for _, p := range paths {
+		if p == "/v2/public/symbols" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,135 @@
+package rest
+
+import (
+	sjson "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error codes returned by the exchange in ret_code
+// https://bybit-exchange.github.io/docs/inverse/#t-errors
+const (
+	CodeTimestampExpired         = 10002  // invalid request, please check your timestamp and recv_window param
+	CodeInvalidSignature         = 10004  // invalid sign
+	CodeTooManyVisits            = 10006  // too many visits
+	CodeIPRateLimited            = 10018  // exceeded the IP rate limit
+	CodeOrderNotExists           = 20001  // order not exists or too late to cancel
+	CodeInsufficientBalance      = 30031  // insufficient available balance for order cost
+	CodeNoOrderFound             = 30034  // no order found
+	CodeInsufficientWallet       = 30042  // insufficient wallet balance
+	CodeInsufficientAvailable    = 30049  // insufficient available balance
+	CodeLinearOrderNotExists     = 130010 // order not exists or too late to replace
+	CodeLinearInsufficientMargin = 130021 // order cost not available
+)
+
+// APIError is returned when the exchange answers with a non-zero ret_code
+// or a non-2xx http status
+type APIError struct {
+	RetCode    int    `json:"ret_code"`
+	RetMsg     string `json:"ret_msg"`
+	ExtCode    string `json:"ext_code"`
+	ExtInfo    string `json:"ext_info"`
+	HTTPStatus int    `json:"http_status"`
+	Endpoint   string `json:"endpoint"`
+}
+
+func (e *APIError) Error() string {
+	if e.RetCode == 0 {
+		return fmt.Sprintf("bybit: %v http status %v: %v", e.Endpoint, e.HTTPStatus, e.RetMsg)
+	}
+	return fmt.Sprintf("bybit: %v ret_code %v: %v", e.Endpoint, e.RetCode, e.RetMsg)
+}
+
+// retStatus is the common header of every response, ext_info may be a string, null or an object
+type retStatus struct {
+	RetCode          *int             `json:"ret_code"`
+	RetMsg           string           `json:"ret_msg"`
+	ExtCode          string           `json:"ext_code"`
+	ExtInfo          sjson.RawMessage `json:"ext_info"`
+	RateLimitStatus  int              `json:"rate_limit_status"`
+	RateLimitResetMs int64            `json:"rate_limit_reset_ms"`
+	RateLimit        int              `json:"rate_limit"`
+}
+
+// parseRetStatus decodes the response header, nil when resp is not a json object
+func parseRetStatus(resp []byte) *retStatus {
+	var r retStatus
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return nil
+	}
+	return &r
+}
+
+// checkResponse turns an error response into an *APIError
+func checkResponse(endpoint string, httpStatus int, r *retStatus) error {
+	if r == nil || r.RetCode == nil {
+		if httpStatus >= http.StatusMultipleChoices {
+			return &APIError{
+				RetMsg:     http.StatusText(httpStatus),
+				HTTPStatus: httpStatus,
+				Endpoint:   endpoint,
+			}
+		}
+		return nil
+	}
+	if *r.RetCode == 0 && httpStatus < http.StatusMultipleChoices {
+		return nil
+	}
+	e := &APIError{
+		RetCode:    *r.RetCode,
+		RetMsg:     r.RetMsg,
+		ExtCode:    r.ExtCode,
+		HTTPStatus: httpStatus,
+		Endpoint:   endpoint,
+	}
+	if len(r.ExtInfo) > 0 && string(r.ExtInfo) != "null" {
+		if err := json.Unmarshal(r.ExtInfo, &e.ExtInfo); err != nil {
+			e.ExtInfo = string(r.ExtInfo)
+		}
+	}
+	return e
+}
+
+func hasRetCode(err error, codes ...int) bool {
+	var e *APIError
+	if !errors.As(err, &e) {
+		return false
+	}
+	for _, code := range codes {
+		if e.RetCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRateLimited reports whether err was caused by hitting the exchange rate limit
+func IsRateLimited(err error) bool {
+	var e *APIError
+	if errors.As(err, &e) && (e.HTTPStatus == http.StatusForbidden || e.HTTPStatus == http.StatusTooManyRequests) {
+		return true
+	}
+	return hasRetCode(err, CodeTooManyVisits, CodeIPRateLimited)
+}
+
+// IsInsufficientBalance reports whether an order was rejected for lack of margin
+func IsInsufficientBalance(err error) bool {
+	return hasRetCode(err, CodeInsufficientBalance, CodeInsufficientWallet, CodeInsufficientAvailable,
+		CodeLinearInsufficientMargin)
+}
+
+// IsOrderNotFound reports whether the referenced order does not exist (anymore)
+func IsOrderNotFound(err error) bool {
+	return hasRetCode(err, CodeOrderNotExists, CodeNoOrderFound, CodeLinearOrderNotExists)
+}
+
+// IsInvalidSignature reports whether the request signature was rejected
+func IsInvalidSignature(err error) bool {
+	return hasRetCode(err, CodeInvalidSignature)
+}
+
+// IsTimestampExpired reports whether the request timestamp was outside of recv_window
+func IsTimestampExpired(err error) bool {
+	return hasRetCode(err, CodeTimestampExpired)
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError_RetCode(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ret_code":130021,"ret_msg":"order cost not available","ext_code":"","ext_info":null,"result":null,"time_now":"1643076385.967696"}`))
+	})
+
+	_, resp, _, err := b.LinearCreateOrder("Buy", "Limit", 35000, 1, "GoodTillCancel", 0,
+		0, false, false, "", "BTCUSDT")
+	assert.NotEmpty(t, resp)
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &apiErr)) {
+		assert.Equal(t, 130021, apiErr.RetCode)
+		assert.Equal(t, "order cost not available", apiErr.RetMsg)
+		assert.Equal(t, http.StatusOK, apiErr.HTTPStatus)
+		assert.Equal(t, "private/linear/order/create", apiErr.Endpoint)
+	}
+	assert.True(t, IsInsufficientBalance(err))
+	assert.False(t, IsRateLimited(err))
+	assert.False(t, IsOrderNotFound(err))
+}
+
+func TestAPIError_PublicEndpoints(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ret_code":10006,"ret_msg":"too many visits","ext_code":"","ext_info":{"detail":"x"},"result":null}`))
+	})
+
+	_, _, _, err := b.GetTickers()
+	assert.True(t, IsRateLimited(err))
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, `{"detail":"x"}`, apiErr.ExtInfo)
+	}
+}
+
+func TestAPIError_HTTPStatus(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html>bad gateway</html>`))
+	})
+
+	_, _, err := b.SetLeverage(3, "BTCUSD")
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, 0, apiErr.RetCode)
+		assert.Equal(t, http.StatusBadGateway, apiErr.HTTPStatus)
+		assert.Equal(t, "user/leverage/save", apiErr.Endpoint)
+	}
+}
+
+func TestAPIError_Helpers(t *testing.T) {
+	assert.True(t, IsTimestampExpired(&APIError{RetCode: CodeTimestampExpired}))
+	assert.True(t, IsInvalidSignature(&APIError{RetCode: CodeInvalidSignature}))
+	assert.True(t, IsOrderNotFound(&APIError{RetCode: CodeLinearOrderNotExists}))
+	assert.True(t, IsRateLimited(&APIError{HTTPStatus: http.StatusForbidden}))
+	assert.False(t, IsRateLimited(errors.New("too many visits")))
+	assert.False(t, IsOrderNotFound(nil))
+}
@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"github.com/wilcosheh/bybit-api/decimal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fundingTime decodes the funding timestamps, unix seconds for inverse and RFC 3339 for linear
+type fundingTime time.Time
+
+func (t *fundingTime) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*t = fundingTime{}
+		return nil
+	}
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*t = fundingTime(time.Unix(sec, 0))
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*t = fundingTime(parsed)
+	return nil
+}
+
+func (f *FundingRate) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Symbol      string          `json:"symbol"`
+		FundingRate decimal.Decimal `json:"funding_rate"`
+		Time        fundingTime     `json:"funding_rate_timestamp"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = FundingRate{
+		Symbol:      raw.Symbol,
+		FundingRate: raw.FundingRate,
+		Time:        time.Time(raw.Time),
+	}
+	return nil
+}
+
+func (f *FundingFee) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Symbol        string          `json:"symbol"`
+		Side          string          `json:"side"`
+		Size          decimal.Decimal `json:"size"`
+		FundingRate   decimal.Decimal `json:"funding_rate"`
+		ExecFee       decimal.Decimal `json:"exec_fee"`
+		ExecTime      fundingTime     `json:"exec_time"`      // linear
+		ExecTimestamp fundingTime     `json:"exec_timestamp"` // inverse
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = FundingFee{
+		Symbol:      raw.Symbol,
+		Side:        raw.Side,
+		Size:        raw.Size,
+		FundingRate: raw.FundingRate,
+		ExecFee:     raw.ExecFee,
+		Time:        time.Time(raw.ExecTime),
+	}
+	if f.Time.IsZero() {
+		f.Time = time.Time(raw.ExecTimestamp)
+	}
+	return nil
+}
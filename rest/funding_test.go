@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByBit_PrevFundingRate(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/public/funding/prev-funding-rate":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"symbol":"BTCUSD","funding_rate":"0.00010000","funding_rate_timestamp":1577433600}}`))
+		case "/public/linear/funding/prev-funding-rate":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"symbol":"BTCUSDT","funding_rate":-0.000125,"funding_rate_timestamp":"2022-01-25T08:00:00.000Z"}}`))
+		}
+	})
+
+	_, _, inverse, err := b.GetPrevFundingRate("BTCUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, "0.0001", inverse.FundingRate.String())
+	assert.True(t, time.Unix(1577433600, 0).Equal(inverse.Time))
+
+	_, _, linear, err := b.LinearGetPrevFundingRate("BTCUSDT")
+	assert.Nil(t, err)
+	assert.Equal(t, "-0.000125", linear.FundingRate.String())
+	assert.True(t, time.Date(2022, 1, 25, 8, 0, 0, 0, time.UTC).Equal(linear.Time))
+}
+
+func TestByBit_MyLastFundingFee(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/private/funding/prev-funding":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"symbol":"BTCUSD","side":"Buy","size":1,"funding_rate":0.0001,"exec_fee":0.00000002,"exec_timestamp":1575907200}}`))
+		case "/private/linear/funding/predicted-funding":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"predicted_funding_rate":0.0001,"predicted_funding_fee":0}}`))
+		}
+	})
+
+	_, _, fee, err := b.GetMyLastFundingFee("BTCUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, "0.00000002", fee.ExecFee.String())
+	assert.True(t, time.Unix(1575907200, 0).Equal(fee.Time))
+
+	_, _, predicted, err := b.LinearGetPredictedFunding("BTCUSDT")
+	assert.Nil(t, err)
+	assert.Equal(t, "0.0001", predicted.PredictedFundingRate.String())
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Host names a group of base urls serving the same endpoints
+type Host string
+
+const (
+	HostMain Host = "main" // v2 inverse and USDT perpetual endpoints
+	HostAPI2 Host = "api2" // website endpoints of api2.go, default to HostMain
+	HostSpot Host = "spot" // spot endpoints, default to HostMain
+)
+
+// Environment maps every host to its base urls, the primary first then its
+// mirrors, which are tried in turn when the primary is unreachable
+type Environment map[Host][]string
+
+// Mainnet is the production environment, with api.bytick.com as mirror of the main host
+var Mainnet = Environment{
+	HostMain: {"https://api.bybit.com/", "https://api.bytick.com/"},
+	HostAPI2: {"https://api2.bybit.com/"},
+	HostSpot: {"https://api.bybit.com/", "https://api.bytick.com/"},
+}
+
+// Testnet is the test environment
+var Testnet = Environment{
+	HostMain: {"https://api-testnet.bybit.com/"},
+	HostAPI2: {"https://api2-testnet.bybit.com/"},
+	HostSpot: {"https://api-testnet.bybit.com/"},
+}
+
+// api2Endpoints are served by HostAPI2
+var api2Endpoints = map[string]bool{
+	"funding-rate/list":                     true,
+	"linear/funding-rate/list":              true,
+	"api/price/index":                       true,
+	"api/premium-index-price/index":         true,
+	"api/linear/public/kline/price":         true,
+	"api/linear/public/kline/premium-price": true,
+}
+
+// endpointHost returns the host serving the endpoint apiURL
+func endpointHost(apiURL string) Host {
+	if api2Endpoints[apiURL] {
+		return HostAPI2
+	}
+	if strings.HasPrefix(apiURL, "spot/") {
+		return HostSpot
+	}
+	return HostMain
+}
+
+// hostSet holds the base urls of a client and the mirror in use for every host
+type hostSet struct {
+	mu     sync.RWMutex
+	urls   map[Host][]string
+	active map[Host]int
+}
+
+func newHostSet(env Environment) *hostSet {
+	h := &hostSet{
+		urls:   map[Host][]string{},
+		active: map[Host]int{},
+	}
+	for host, urls := range env {
+		h.set(host, urls)
+	}
+	return h
+}
+
+func (h *hostSet) set(host Host, urls []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(urls) == 0 {
+		delete(h.urls, host)
+	} else {
+		h.urls[host] = append([]string(nil), urls...)
+	}
+	delete(h.active, host)
+}
+
+// resolve returns the host actually serving host, which falls back to HostMain
+// when it has no base url
+func (h *hostSet) resolve(host Host) Host {
+	if len(h.urls[host]) == 0 {
+		return HostMain
+	}
+	return host
+}
+
+// bases returns the base urls of host, the one in use first
+func (h *hostSet) bases(host Host) (Host, []string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	host = h.resolve(host)
+	urls := h.urls[host]
+	active := h.active[host]
+	bases := make([]string, 0, len(urls))
+	bases = append(bases, urls[active:]...)
+	bases = append(bases, urls[:active]...)
+	return host, bases
+}
+
+// use makes base the base url in use of host
+func (h *hostSet) use(host Host, base string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, u := range h.urls[host] {
+		if u == base {
+			h.active[host] = i
+			return
+		}
+	}
+}
+
+// NewWithEnvironment creates a client on the hosts of env, e.g. Mainnet or Testnet
+func NewWithEnvironment(httpClient *http.Client, env Environment, apiKey string, secretKey string, debugMode bool) *ByBit {
+	b := New(httpClient, "", apiKey, secretKey, debugMode)
+	b.hosts = newHostSet(env)
+	return b
+}
+
+// SetHost sets the base urls of host, the primary first then its mirrors.
+// No base url makes host fall back to HostMain.
+func (b *ByBit) SetHost(host Host, baseURLs ...string) {
+	b.hosts.set(host, baseURLs)
+}
+
+// BaseURL returns the base url in use for host, "" if none is set
+func (b *ByBit) BaseURL(host Host) string {
+	_, bases := b.hosts.bases(host)
+	if len(bases) == 0 {
+		return ""
+	}
+	return bases[0]
+}
+
+// shouldFailover reports whether a request failing with err may be resent to a
+// mirror: any transport error for GETs, only failed dials otherwise, as the
+// request then never reached the exchange
+func shouldFailover(ctx context.Context, method string, err error) bool {
+	var apiErr *APIError
+	if err == nil || ctx.Err() != nil || errors.As(err, &apiErr) {
+		return false
+	}
+	if method == http.MethodGet {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
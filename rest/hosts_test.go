@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByBit_EndpointHost(t *testing.T) {
+	var mainPaths, api2Paths []string
+	main := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mainPaths = append(mainPaths, r.URL.Path)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[]}`))
+	}))
+	defer main.Close()
+	api2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api2Paths = append(api2Paths, r.URL.Path)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[]}`))
+	}))
+	defer api2.Close()
+
+	b := NewWithEnvironment(nil, Environment{HostMain: {main.URL + "/"}}, "testKey", "testSecret", false)
+	_, _, _, err := b.GetPriceIndex("BTCUSD", "1", 0, 60)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/api/price/index"}, mainPaths, "api2 falls back to main")
+
+	b.SetHost(HostAPI2, api2.URL+"/")
+	_, _, _, err = b.GetPriceIndex("BTCUSD", "1", 0, 60)
+	assert.Nil(t, err)
+	_, _, _, err = b.GetSymbols()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/api/price/index"}, api2Paths)
+	assert.Equal(t, []string{"/api/price/index", "/v2/public/symbols"}, mainPaths)
+}
+
+func TestByBit_HostFailover(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	requests := 0
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[]}`))
+			return
+		}
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"user_id":1,"order_id":"1","symbol":"BTCUSDT"}}`))
+	}))
+	defer mirror.Close()
+
+	b := New(nil, down.URL+"/", "testKey", "testSecret", false)
+	b.SetHost(HostMain, down.URL+"/", mirror.URL+"/")
+	_, _, _, err := b.LinearGetKLine("BTCUSDT", "1", 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, mirror.URL+"/", b.BaseURL(HostMain), "the mirror stays in use")
+
+	// the primary was refused the connection, a POST may be resent
+	b.SetHost(HostMain, down.URL+"/", mirror.URL+"/")
+	query, _, order, err := b.LinearCreateOrder("Buy", "Limit", 30000, 0.001, "GoodTillCancel", 0, 0, false, false, "", "BTCUSDT")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", order.OrderId)
+	assert.Equal(t, mirror.URL+"/private/linear/order/create", query)
+	assert.Equal(t, 2, requests)
+}
+
+func TestShouldFailover(t *testing.T) {
+	b := New(nil, "", "", "", false)
+	ctx := b.Context()
+	assert.False(t, shouldFailover(ctx, http.MethodGet, nil))
+	assert.False(t, shouldFailover(ctx, http.MethodGet, &APIError{HTTPStatus: http.StatusBadGateway}))
+	assert.False(t, shouldFailover(ctx, http.MethodPost, &APIError{HTTPStatus: http.StatusBadGateway}))
+	read := &url.Error{Op: "Post", URL: "https://api.bybit.com/", Err: &net.OpError{Op: "read", Err: io.ErrUnexpectedEOF}}
+	assert.True(t, shouldFailover(ctx, http.MethodGet, read))
+	assert.False(t, shouldFailover(ctx, http.MethodPost, read), "the POST may have reached the exchange")
+	dial := &url.Error{Op: "Post", URL: "https://api.bybit.com/", Err: &net.OpError{Op: "dial", Err: io.ErrUnexpectedEOF}}
+	assert.True(t, shouldFailover(ctx, http.MethodPost, dial))
+}
@@ -0,0 +1,186 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wilcosheh/bybit-api/decimal"
+)
+
+// ErrInvalidOrder is wrapped by the errors of the pre-flight order validation
+var ErrInvalidOrder = errors.New("bybit: invalid order")
+
+// OrderValidation selects how orders are checked against the SymbolInfo filters before being sent
+type OrderValidation int
+
+const (
+	OrderValidationOff       OrderValidation = iota // send orders as they are
+	OrderValidationStrict                           // reject orders violating tick size, qty step or bounds
+	OrderValidationAutoRound                        // round price to tick and qty down to step, then check bounds
+)
+
+// floatPlaces drops the binary noise of float prices and quantities, such as
+// the 0.30000000000000004 of 0.1+0.2, when they are turned into decimals
+const floatPlaces = 12
+
+// toDecimal returns v as a decimal, see floatPlaces
+func toDecimal(v float64) decimal.Decimal {
+	return decimal.NewFromFloat(v).Round(floatPlaces)
+}
+
+// RoundPrice rounds price to the nearest tick size
+func (s SymbolInfo) RoundPrice(price float64) float64 {
+	tick := s.PriceFilter.TickSize
+	if tick.Sign() <= 0 {
+		return price
+	}
+	return toDecimal(price).RoundStep(tick).Float64()
+}
+
+// RoundQty rounds qty down to the qty step, so the order never exceeds the intended size
+func (s SymbolInfo) RoundQty(qty float64) float64 {
+	step := s.LotSizeFilter.QtyStep
+	if step.Sign() <= 0 {
+		return qty
+	}
+	return toDecimal(qty).FloorStep(step).Float64()
+}
+
+// CheckPrice checks price against the price bounds and tick size
+func (s SymbolInfo) CheckPrice(price float64) error {
+	f := s.PriceFilter
+	p := toDecimal(price)
+	if p.LessThan(f.MinPrice) || (f.MaxPrice.Sign() > 0 && p.GreaterThan(f.MaxPrice)) {
+		return fmt.Errorf("%w: %v price %v out of [%v, %v]", ErrInvalidOrder, s.Name, p, f.MinPrice, f.MaxPrice)
+	}
+	if !onStep(p, f.TickSize) {
+		return fmt.Errorf("%w: %v price %v is not a multiple of tick size %v", ErrInvalidOrder, s.Name, p, f.TickSize)
+	}
+	return nil
+}
+
+// CheckQty checks qty against the min/max trading qty and qty step
+func (s SymbolInfo) CheckQty(qty float64) error {
+	f := s.LotSizeFilter
+	q := toDecimal(qty)
+	if q.LessThan(f.MinTradingQty) || (f.MaxTradingQty.Sign() > 0 && q.GreaterThan(f.MaxTradingQty)) {
+		return fmt.Errorf("%w: %v qty %v out of [%v, %v]", ErrInvalidOrder, s.Name, q, f.MinTradingQty, f.MaxTradingQty)
+	}
+	if !onStep(q, f.QtyStep) {
+		return fmt.Errorf("%w: %v qty %v is not a multiple of qty step %v", ErrInvalidOrder, s.Name, q, f.QtyStep)
+	}
+	return nil
+}
+
+// CheckLeverage checks leverage against the leverage bounds
+func (s SymbolInfo) CheckLeverage(leverage float64) error {
+	f := s.LeverageFilter
+	if leverage < float64(f.MinLeverage) || (f.MaxLeverage > 0 && leverage > float64(f.MaxLeverage)) {
+		return fmt.Errorf("%w: %v leverage %v out of [%v, %v]", ErrInvalidOrder, s.Name, leverage, f.MinLeverage, f.MaxLeverage)
+	}
+	return nil
+}
+
+func onStep(v decimal.Decimal, step decimal.Decimal) bool {
+	if step.Sign() <= 0 {
+		return true
+	}
+	return v.RoundStep(step).Equal(v)
+}
+
+type instrumentCache struct {
+	mu       sync.RWMutex
+	symbols  map[string]SymbolInfo
+	loadedAt time.Time
+}
+
+func newInstrumentCache() *instrumentCache {
+	return &instrumentCache{
+		symbols: map[string]SymbolInfo{},
+	}
+}
+
+// LoadInstruments (re)loads the instrument cache from GetSymbols
+func (b *ByBit) LoadInstruments() (err error) {
+	_, _, symbols, err := b.GetSymbols()
+	if err != nil {
+		return
+	}
+	m := make(map[string]SymbolInfo, len(symbols))
+	for _, s := range symbols {
+		m[s.Name] = s
+	}
+
+	b.instruments.mu.Lock()
+	b.instruments.symbols = m
+	b.instruments.loadedAt = time.Now()
+	b.instruments.mu.Unlock()
+	return
+}
+
+// Instrument returns the cached SymbolInfo of symbol, see LoadInstruments
+func (b *ByBit) Instrument(symbol string) (info SymbolInfo, ok bool) {
+	b.instruments.mu.RLock()
+	defer b.instruments.mu.RUnlock()
+	info, ok = b.instruments.symbols[symbol]
+	return
+}
+
+// SetOrderValidation sets the pre-flight validation of CreateOrder/LinearCreateOrder,
+// the instrument cache is loaded on first use if LoadInstruments was not called
+func (b *ByBit) SetOrderValidation(mode OrderValidation) {
+	b.validation = mode
+}
+
+func (b *ByBit) instrument(symbol string) (info SymbolInfo, err error) {
+	info, ok := b.Instrument(symbol)
+	if ok {
+		return
+	}
+	b.instruments.mu.RLock()
+	loaded := !b.instruments.loadedAt.IsZero()
+	b.instruments.mu.RUnlock()
+	if !loaded {
+		if err = b.LoadInstruments(); err != nil {
+			return
+		}
+		if info, ok = b.Instrument(symbol); ok {
+			return
+		}
+	}
+	err = fmt.Errorf("%w: unknown symbol %v", ErrInvalidOrder, symbol)
+	return
+}
+
+// preflightOrder validates, or rounds then validates, qty and the non-zero prices
+// of an order according to the validation mode
+func (b *ByBit) preflightOrder(symbol string, qty *float64, prices ...*float64) error {
+	if b.validation == OrderValidationOff {
+		return nil
+	}
+	info, err := b.instrument(symbol)
+	if err != nil {
+		return err
+	}
+	if b.validation == OrderValidationAutoRound {
+		*qty = info.RoundQty(*qty)
+		for _, p := range prices {
+			if *p > 0 {
+				*p = info.RoundPrice(*p)
+			}
+		}
+	}
+	if err := info.CheckQty(*qty); err != nil {
+		return err
+	}
+	for _, p := range prices {
+		if *p > 0 {
+			if err := info.CheckPrice(*p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wilcosheh/bybit-api/decimal"
+)
+
+const testSymbolsBody = `{"ret_code":0,"ret_msg":"OK","result":[
+{"name":"BTCUSD","base_currency":"BTC","quote_currency":"USD","price_scale":2,"taker_fee":"0.00075","maker_fee":"-0.00025",
+"leverage_filter":{"min_leverage":1,"max_leverage":100,"leverage_step":"0.01"},
+"price_filter":{"min_price":"0.5","max_price":"999999","tick_size":"0.5"},
+"lot_size_filter":{"max_trading_qty":1000000,"min_trading_qty":1,"qty_step":1}},
+{"name":"BTCUSDT","base_currency":"BTC","quote_currency":"USDT","price_scale":2,"taker_fee":"0.00075","maker_fee":"-0.00025",
+"leverage_filter":{"min_leverage":1,"max_leverage":100,"leverage_step":"0.01"},
+"price_filter":{"min_price":"0.5","max_price":"999999","tick_size":"0.5"},
+"lot_size_filter":{"max_trading_qty":100,"min_trading_qty":0.001,"qty_step":0.001}}]}`
+
+func TestSymbolInfo_Rounding(t *testing.T) {
+	s := SymbolInfo{
+		Name: "BTCUSDT",
+		PriceFilter: PriceFilter{
+			MinPrice: decimal.RequireFromString("0.5"),
+			MaxPrice: decimal.RequireFromString("999999"),
+			TickSize: decimal.RequireFromString("0.5"),
+		},
+		LotSizeFilter: LotSizeFilter{
+			MinTradingQty: decimal.RequireFromString("0.001"),
+			MaxTradingQty: decimal.RequireFromString("100"),
+			QtyStep:       decimal.RequireFromString("0.001"),
+		},
+		LeverageFilter: LeverageFilter{MinLeverage: 1, MaxLeverage: 100},
+	}
+	assert.Equal(t, 35000.5, s.RoundPrice(35000.7))
+	assert.Equal(t, 35001.0, s.RoundPrice(35000.8))
+	assert.Equal(t, 0.123, s.RoundQty(0.1239))
+	assert.Equal(t, 0.3, s.RoundQty(0.3))
+	// float noise neither rounds down a step nor fails the step check
+	a, b := 0.7, 0.4
+	assert.Equal(t, 0.3, s.RoundQty(a-b))
+	assert.Nil(t, s.CheckQty(a-b))
+
+	assert.Nil(t, s.CheckPrice(35000.5))
+	assert.True(t, errors.Is(s.CheckPrice(35000.7), ErrInvalidOrder))
+	assert.Nil(t, s.CheckQty(0.3))
+	assert.NotNil(t, s.CheckQty(0.0005))
+	assert.NotNil(t, s.CheckQty(101))
+	assert.Nil(t, s.CheckLeverage(12.5))
+	assert.NotNil(t, s.CheckLeverage(125))
+}
+
+func TestByBit_OrderValidation(t *testing.T) {
+	var symbols, creates int32
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/public/symbols":
+			atomic.AddInt32(&symbols, 1)
+			w.Write([]byte(testSymbolsBody))
+		case "/private/linear/order/create":
+			atomic.AddInt32(&creates, 1)
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"abc"}}`))
+		}
+	})
+	req := LinearCreateOrderRequest{
+		Symbol:    "BTCUSDT",
+		Side:      SideBuy,
+		OrderType: OrderTypeLimit,
+		Qty:       0.0125,
+		Price:     35000.7,
+	}
+
+	b.SetOrderValidation(OrderValidationStrict)
+	_, _, _, err := b.LinearPlaceOrder(req)
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&creates))
+
+	b.SetOrderValidation(OrderValidationAutoRound)
+	_, _, _, err = b.LinearPlaceOrder(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.012, body["qty"])
+	assert.Equal(t, 35000.5, body["price"])
+
+	req.Symbol = "NOPEUSDT"
+	_, _, _, err = b.LinearPlaceOrder(req)
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+
+	// the cache is loaded once, lazily
+	assert.Equal(t, int32(1), atomic.LoadInt32(&symbols))
+	info, ok := b.Instrument("BTCUSD")
+	assert.True(t, ok)
+	assert.Equal(t, "1", info.LotSizeFilter.QtyStep.String())
+}
@@ -0,0 +1,467 @@
+package rest
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+const defaultPageSize = 50
+
+// HistoryFilter selects the records streamed by the history iterators
+type HistoryFilter struct {
+	Symbol   string    // symbol, or currency for WalletRecordsIter
+	Status   string    // order_status / stop_order_status, optional
+	ExecType string    // exec_type of executions and closed PnL, optional
+	From     time.Time // oldest record time, optional
+	To       time.Time // newest record time, optional
+	PageSize int       // records per request, 50 by default
+}
+
+func (f HistoryFilter) pageSize() int {
+	if f.PageSize > 0 {
+		return f.PageSize
+	}
+	return defaultPageSize
+}
+
+// pager walks the pages of a history endpoint, the typed iterators supply
+// fetch, which loads the next page into their buffer, and at, the time of
+// record i of that page. Histories are returned newest first, so records
+// newer than To are skipped and the first record older than From ends the
+// iteration.
+type pager struct {
+	b        *ByBit
+	endpoint string
+	from     time.Time
+	to       time.Time
+	fetch    func() (n int, last bool, err error)
+	at       func(i int) time.Time
+
+	n, i    int
+	fetched bool
+	done    bool
+	err     error
+}
+
+func (p *pager) next() (i int, ok bool) {
+	for {
+		if p.err != nil {
+			return
+		}
+		if p.i < p.n {
+			i = p.i
+			p.i++
+			t := p.at(i)
+			if t.IsZero() {
+				return i, true
+			}
+			if !p.to.IsZero() && t.After(p.to) {
+				continue
+			}
+			if !p.from.IsZero() && t.Before(p.from) {
+				p.n, p.done = 0, true
+				return
+			}
+			return i, true
+		}
+		if p.done {
+			return
+		}
+		if err := p.waitQuota(); err != nil {
+			p.err = err
+			return
+		}
+		n, last, err := p.fetch()
+		if err != nil {
+			p.err = err
+			return
+		}
+		p.n, p.i, p.fetched = n, 0, true
+		p.done = last || n == 0
+	}
+}
+
+// waitQuota waits for the reset of an exhausted exchange quota before the next page
+func (p *pager) waitQuota() error {
+	ctx := p.b.Context()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !p.fetched {
+		return nil
+	}
+	if s, ok := p.b.RateLimitState(p.endpoint); ok && s.Exhausted(time.Now()) {
+		return sleepContext(ctx, time.Until(s.ResetAt))
+	}
+	return nil
+}
+
+// Err returns the error that ended the iteration, nil once all records were read
+func (p *pager) Err() error {
+	return p.err
+}
+
+func newPager(ctx context.Context, b *ByBit, endpoint string, filter HistoryFilter) pager {
+	return pager{
+		b:        b.WithContext(ctx),
+		endpoint: endpoint,
+		from:     filter.From,
+		to:       filter.To,
+	}
+}
+
+// OrderIter streams orders, see OrdersIter and LinearOrdersIter
+//
+//	it := b.OrdersIter(ctx, rest.HistoryFilter{Symbol: "BTCUSD"})
+//	for it.Next() {
+//		order := it.Order()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type OrderIter struct {
+	pager
+	page []Order
+	cur  Order
+}
+
+// Next advances to the next order, it returns false at the end or on error
+func (it *OrderIter) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.page[i]
+	}
+	return ok
+}
+
+// Order returns the current order
+func (it *OrderIter) Order() Order {
+	return it.cur
+}
+
+// OrdersIter iterates the inverse order history, following the cursor of GetOrders
+func (b *ByBit) OrdersIter(ctx context.Context, filter HistoryFilter) *OrderIter {
+	it := &OrderIter{}
+	it.pager = newPager(ctx, b, "v2/private/order/list", filter)
+	cursor := ""
+	it.fetch = func() (n int, last bool, err error) {
+		_, _, result, err := it.b.GetOrders(filter.Symbol, filter.Status, "", filter.pageSize(), cursor)
+		if err != nil {
+			return
+		}
+		it.page = result.Data
+		cursor = result.Cursor
+		return len(it.page), cursor == "" || len(it.page) < filter.pageSize(), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].Created()
+	}
+	return it
+}
+
+// LinearOrdersIter iterates the USDT perpetual order history, following the pages of LinearGetOrders
+func (b *ByBit) LinearOrdersIter(ctx context.Context, filter HistoryFilter) *OrderIter {
+	it := &OrderIter{}
+	it.pager = newPager(ctx, b, "private/linear/order/list", filter)
+	page := 0
+	it.fetch = func() (n int, last bool, err error) {
+		page++
+		_, _, result, err := it.b.LinearGetOrders(filter.Symbol, filter.Status, filter.pageSize(), page)
+		if err != nil {
+			return
+		}
+		it.page = result.Data
+		return len(it.page), lastPage(page, result.LastPage.String()), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].Created()
+	}
+	return it
+}
+
+// StopOrderIter streams conditional orders, see StopOrdersIter and LinearStopOrdersIter
+type StopOrderIter struct {
+	pager
+	page []StopOrder
+	cur  StopOrder
+}
+
+// Next advances to the next stop order, it returns false at the end or on error
+func (it *StopOrderIter) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.page[i]
+	}
+	return ok
+}
+
+// StopOrder returns the current stop order
+func (it *StopOrderIter) StopOrder() StopOrder {
+	return it.cur
+}
+
+// StopOrdersIter iterates the inverse conditional order history, following the cursor of GetStopOrders
+func (b *ByBit) StopOrdersIter(ctx context.Context, filter HistoryFilter) *StopOrderIter {
+	it := &StopOrderIter{}
+	it.pager = newPager(ctx, b, "v2/private/stop-order/list", filter)
+	cursor := ""
+	it.fetch = func() (n int, last bool, err error) {
+		_, _, result, err := it.b.GetStopOrders(filter.Symbol, filter.Status, "", filter.pageSize(), cursor)
+		if err != nil {
+			return
+		}
+		it.page = result.Data
+		cursor = result.Cursor
+		return len(it.page), cursor == "" || len(it.page) < filter.pageSize(), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].Created()
+	}
+	return it
+}
+
+// LinearStopOrdersIter iterates the USDT perpetual conditional order history, following the pages of LinearGetStopOrders
+func (b *ByBit) LinearStopOrdersIter(ctx context.Context, filter HistoryFilter) *StopOrderIter {
+	it := &StopOrderIter{}
+	it.pager = newPager(ctx, b, "private/linear/stop-order/list", filter)
+	page := 0
+	it.fetch = func() (n int, last bool, err error) {
+		page++
+		_, _, result, err := it.b.LinearGetStopOrders(filter.Symbol, filter.Status, filter.pageSize(), page)
+		if err != nil {
+			return
+		}
+		it.page = result.Data
+		return len(it.page), lastPage(page, result.LastPage.String()), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].Created()
+	}
+	return it
+}
+
+// WalletRecordIter streams wallet fund records, see WalletRecordsIter
+type WalletRecordIter struct {
+	pager
+	page []WalletFundRecord
+	cur  WalletFundRecord
+}
+
+// Next advances to the next record, it returns false at the end or on error
+func (it *WalletRecordIter) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.page[i]
+	}
+	return ok
+}
+
+// Record returns the current record
+func (it *WalletRecordIter) Record() WalletFundRecord {
+	return it.cur
+}
+
+// WalletRecordsIter iterates the wallet fund records of the currency filter.Symbol, following the pages of WalletRecords
+func (b *ByBit) WalletRecordsIter(ctx context.Context, filter HistoryFilter) *WalletRecordIter {
+	it := &WalletRecordIter{}
+	it.pager = newPager(ctx, b, "open-api/wallet/fund/records", filter)
+	page := 0
+	it.fetch = func() (n int, last bool, err error) {
+		page++
+		_, _, result, err := it.b.WalletRecords(filter.Symbol, page, filter.pageSize())
+		if err != nil {
+			return
+		}
+		it.page = result
+		return len(it.page), len(it.page) < filter.pageSize(), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].ExecTime
+	}
+	return it
+}
+
+// FundingIter streams funding rates, see FundingRatesIter
+type FundingIter struct {
+	pager
+	page []FundingData
+	cur  FundingData
+}
+
+// Next advances to the next funding rate, it returns false at the end or on error
+func (it *FundingIter) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.page[i]
+	}
+	return ok
+}
+
+// Funding returns the current funding rate
+func (it *FundingIter) Funding() FundingData {
+	return it.cur
+}
+
+// FundingRatesIter iterates the funding rate history of filter.Symbol, following the pages of GetFunding
+func (b *ByBit) FundingRatesIter(ctx context.Context, filter HistoryFilter) *FundingIter {
+	it := &FundingIter{}
+	it.pager = newPager(ctx, b, "funding-rate/list", filter)
+	page := 0
+	it.fetch = func() (n int, last bool, err error) {
+		page++
+		_, _, result, err := it.b.GetFunding(filter.Symbol, page, filter.pageSize())
+		if err != nil {
+			return
+		}
+		it.page = result
+		return len(it.page), len(it.page) < filter.pageSize(), nil
+	}
+	it.at = func(i int) time.Time {
+		return parseRecordTime(it.page[i].Time)
+	}
+	return it
+}
+
+// ExecutionIter streams fills, see ExecutionsIter and LinearExecutionsIter
+type ExecutionIter struct {
+	pager
+	page []Execution
+	cur  Execution
+}
+
+// Next advances to the next fill, it returns false at the end or on error
+func (it *ExecutionIter) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.page[i]
+	}
+	return ok
+}
+
+// Execution returns the current fill
+func (it *ExecutionIter) Execution() Execution {
+	return it.cur
+}
+
+// ExecutionsIter iterates the inverse fills of filter.Symbol, following the pages of GetExecutions
+func (b *ByBit) ExecutionsIter(ctx context.Context, filter HistoryFilter) *ExecutionIter {
+	it := &ExecutionIter{}
+	it.pager = newPager(ctx, b, "v2/private/execution/list", filter)
+	page := 0
+	it.fetch = func() (n int, last bool, err error) {
+		page++
+		_, _, result, err := it.b.GetExecutions(filter.Symbol, "", unixMilli(filter.From), page, filter.pageSize())
+		if err != nil {
+			return
+		}
+		it.page = result
+		return len(it.page), len(it.page) < filter.pageSize(), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].TradeTime
+	}
+	return it
+}
+
+// LinearExecutionsIter iterates the USDT perpetual fills of filter.Symbol, following the pages of LinearGetExecutions
+func (b *ByBit) LinearExecutionsIter(ctx context.Context, filter HistoryFilter) *ExecutionIter {
+	it := &ExecutionIter{}
+	it.pager = newPager(ctx, b, "private/linear/trade/execution/list", filter)
+	page := 0
+	it.fetch = func() (n int, last bool, err error) {
+		page++
+		_, _, result, err := it.b.LinearGetExecutions(filter.Symbol, unixMilli(filter.From), unixMilli(filter.To),
+			filter.ExecType, page, filter.pageSize())
+		if err != nil {
+			return
+		}
+		it.page = result
+		return len(it.page), len(it.page) < filter.pageSize(), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].TradeTime
+	}
+	return it
+}
+
+// ClosedPnlIter streams closed PnL records, see ClosedPnlsIter and LinearClosedPnlsIter
+type ClosedPnlIter struct {
+	pager
+	page []ClosedPnl
+	cur  ClosedPnl
+}
+
+// Next advances to the next record, it returns false at the end or on error
+func (it *ClosedPnlIter) Next() bool {
+	i, ok := it.next()
+	if ok {
+		it.cur = it.page[i]
+	}
+	return ok
+}
+
+// ClosedPnl returns the current record
+func (it *ClosedPnlIter) ClosedPnl() ClosedPnl {
+	return it.cur
+}
+
+// ClosedPnlsIter iterates the inverse closed PnL of filter.Symbol, following the pages of GetClosedPnl
+func (b *ByBit) ClosedPnlsIter(ctx context.Context, filter HistoryFilter) *ClosedPnlIter {
+	return b.closedPnlsIter(ctx, filter, "v2/private/trade/closed-pnl/list", (*ByBit).GetClosedPnl)
+}
+
+// LinearClosedPnlsIter iterates the USDT perpetual closed PnL of filter.Symbol, following the pages of LinearGetClosedPnl
+func (b *ByBit) LinearClosedPnlsIter(ctx context.Context, filter HistoryFilter) *ClosedPnlIter {
+	return b.closedPnlsIter(ctx, filter, "private/linear/trade/closed-pnl/list", (*ByBit).LinearGetClosedPnl)
+}
+
+func (b *ByBit) closedPnlsIter(ctx context.Context, filter HistoryFilter, endpoint string,
+	get func(*ByBit, string, int64, int64, string, int, int) (string, []byte, []ClosedPnl, error)) *ClosedPnlIter {
+	it := &ClosedPnlIter{}
+	it.pager = newPager(ctx, b, endpoint, filter)
+	page := 0
+	it.fetch = func() (n int, last bool, err error) {
+		page++
+		_, _, result, err := get(it.b, filter.Symbol, unix(filter.From), unix(filter.To), filter.ExecType, page, filter.pageSize())
+		if err != nil {
+			return
+		}
+		it.page = result
+		return len(it.page), len(it.page) < filter.pageSize(), nil
+	}
+	it.at = func(i int) time.Time {
+		return it.page[i].Created()
+	}
+	return it
+}
+
+// unix returns the seconds of t, 0 for the zero time
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// unixMilli returns the milliseconds of t, 0 for the zero time
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func lastPage(page int, last string) bool {
+	n, err := strconv.Atoi(last)
+	return err == nil && page >= n
+}
+
+// parseRecordTime parses the RFC 3339 or "2006-01-02 15:04:05" times of the
+// history records, zero if it cannot
+func parseRecordTime(s string) time.Time {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	t, _ := time.Parse("2006-01-02 15:04:05", s)
+	return t
+}
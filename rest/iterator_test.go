@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// linearOrdersPage serves 3 pages of 2 orders, one per hour from 10:00 down to 05:00
+func linearOrdersPage(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	var data []string
+	for i := 0; i < 2; i++ {
+		hour := 10 - (page-1)*2 - i
+		data = append(data, fmt.Sprintf(`{"order_id":"o%d","created_time":"2022-01-25T%02d:00:00Z"}`, hour, hour))
+	}
+	fmt.Fprintf(w, `{"ret_code":0,"ret_msg":"OK","result":{"current_page":%d,"last_page":3,"data":[%s]}}`,
+		page, strings.Join(data, ","))
+}
+
+func TestLinearOrdersIter(t *testing.T) {
+	var pages []string
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		linearOrdersPage(w, r)
+	})
+
+	var ids []string
+	it := b.LinearOrdersIter(context.Background(), HistoryFilter{Symbol: "BTCUSDT", PageSize: 2})
+	for it.Next() {
+		ids = append(ids, it.Order().OrderId)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"o10", "o9", "o8", "o7", "o6", "o5"}, ids)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+}
+
+func TestLinearOrdersIter_TimeBounds(t *testing.T) {
+	var requests int
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		linearOrdersPage(w, r)
+	})
+
+	var ids []string
+	it := b.LinearOrdersIter(context.Background(), HistoryFilter{
+		Symbol:   "BTCUSDT",
+		From:     time.Date(2022, 1, 25, 8, 0, 0, 0, time.UTC),
+		To:       time.Date(2022, 1, 25, 9, 0, 0, 0, time.UTC),
+		PageSize: 2,
+	})
+	for it.Next() {
+		ids = append(ids, it.Order().OrderId)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"o9", "o8"}, ids)
+	// o7 is older than From, the third page is never requested
+	assert.Equal(t, 2, requests)
+}
+
+func TestOrdersIter_Cursor(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"data":[{"order_id":"a"},{"order_id":"b"}],"cursor":"c1"}}`))
+		case "c1":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"data":[{"order_id":"c"}],"cursor":"c2"}}`))
+		default:
+			t.Errorf("unexpected cursor %v", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	var ids []string
+	it := b.OrdersIter(context.Background(), HistoryFilter{Symbol: "BTCUSD", PageSize: 2})
+	for it.Next() {
+		ids = append(ids, it.Order().OrderId)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+}
+
+func TestOrdersIter_RateLimitAndCancel(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		// the quota is used up until well after the test deadline
+		w.Header().Set("X-Bapi-Limit", "10")
+		w.Header().Set("X-Bapi-Limit-Status", "0")
+		w.Header().Set("X-Bapi-Limit-Reset-Timestamp", strconv.FormatInt(time.Now().Add(time.Minute).UnixNano()/1e6, 10))
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"data":[{"order_id":"a"}],"cursor":"c1"}}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	it := b.OrdersIter(ctx, HistoryFilter{Symbol: "BTCUSD", PageSize: 1})
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.Equal(t, context.DeadlineExceeded, it.Err())
+}
+
+func TestLinearExecutionsIter(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/private/linear/trade/execution/list", r.URL.Path)
+		assert.Equal(t, "1643076000000", r.URL.Query().Get("start_time"))
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"current_page":1,"data":[
+{"symbol":"BTCUSDT","side":"Buy","order_id":"o1","exec_id":"e2","exec_price":35000.5,"exec_qty":0.002,"exec_fee":0.0525,"last_liquidity_ind":"RemovedLiquidity","trade_time_ms":1643076385000},
+{"symbol":"BTCUSDT","side":"Buy","order_id":"o1","exec_id":"e1","exec_price":35000,"exec_qty":0.001,"exec_fee":-0.00875,"last_liquidity_ind":"AddedLiquidity","trade_time_ms":1643076384000}]}}`))
+		default:
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"current_page":2,"data":null}}`))
+		}
+	})
+
+	var fills []Execution
+	it := b.LinearExecutionsIter(context.Background(), HistoryFilter{
+		Symbol:   "BTCUSDT",
+		From:     time.Unix(1643076000, 0),
+		PageSize: 2,
+	})
+	for it.Next() {
+		fills = append(fills, it.Execution())
+	}
+	assert.Nil(t, it.Err())
+	if assert.Len(t, fills, 2) {
+		assert.Equal(t, "e2", fills[0].ExecID)
+		assert.Equal(t, "35000.5", fills[0].Price.String())
+		assert.False(t, fills[0].IsMaker)
+		assert.True(t, fills[1].IsMaker)
+		assert.Equal(t, "-0.00875", fills[1].ExecFee.String())
+		assert.Equal(t, int64(1643076384), fills[1].TradeTime.Unix())
+	}
+}
+
+func TestClosedPnlsIter(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/private/trade/closed-pnl/list", r.URL.Path)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"current_page":1,"data":[
+{"id":2,"symbol":"BTCUSD","closed_pnl":"0.00001234","created_at":1643076385},
+{"id":1,"symbol":"BTCUSD","closed_pnl":-0.5,"created_at":1643070000}]}}`))
+	})
+
+	var ids []int64
+	it := b.ClosedPnlsIter(context.Background(), HistoryFilter{Symbol: "BTCUSD", To: time.Unix(1643076000, 0)})
+	for it.Next() {
+		ids = append(ids, it.ClosedPnl().Id)
+		assert.Equal(t, "-0.5", it.ClosedPnl().ClosedPnl.String())
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int64{1}, ids)
+}
@@ -0,0 +1,191 @@
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wilcosheh/bybit-api/decimal"
+)
+
+// klinePageSize is the max limit of the kline endpoints
+const klinePageSize = 200
+
+// Candle is a kline normalised from OHLC and OHLCLinear
+type Candle struct {
+	Symbol   string          `json:"symbol"`
+	Interval string          `json:"interval"`
+	OpenTime time.Time       `json:"open_time"`
+	Open     decimal.Decimal `json:"open"`
+	High     decimal.Decimal `json:"high"`
+	Low      decimal.Decimal `json:"low"`
+	Close    decimal.Decimal `json:"close"`
+	Volume   decimal.Decimal `json:"volume"`
+	Turnover decimal.Decimal `json:"turnover"`
+}
+
+// Candle normalises an inverse kline
+func (o *OHLC) Candle() Candle {
+	return Candle{
+		Symbol:   o.Symbol,
+		Interval: o.Interval,
+		OpenTime: time.Unix(o.OpenTime, 0).UTC(),
+		Open:     o.Open,
+		High:     o.High,
+		Low:      o.Low,
+		Close:    o.Close,
+		Volume:   o.Volume,
+		Turnover: o.Turnover,
+	}
+}
+
+// Candle normalises a USDT perpetual kline
+func (o *OHLCLinear) Candle() Candle {
+	return Candle{
+		Symbol:   o.Symbol,
+		Interval: o.Period,
+		OpenTime: time.Unix(o.OpenTime, 0).UTC(),
+		Open:     o.Open,
+		High:     o.High,
+		Low:      o.Low,
+		Close:    o.Close,
+		Volume:   o.Volume,
+		Turnover: o.Turnover,
+	}
+}
+
+// KlineGap is a run of candles missing from a KlineRange
+type KlineGap struct {
+	Start   time.Time `json:"start"`   // open time of the first missing candle
+	End     time.Time `json:"end"`     // open time of the candle following the gap
+	Missing int       `json:"missing"` // number of missing candles
+}
+
+// KlineRange downloads the inverse candles of symbol opening in [start, end),
+// paging forward from start. Overlapping candles are dropped and the runs of
+// missing candles are reported in gaps.
+// interval: 1 3 5 15 30 60 120 240 360 720 D W M
+func (b *ByBit) KlineRange(symbol string, interval string, start time.Time, end time.Time) (candles []Candle, gaps []KlineGap, err error) {
+	return b.klineRange(interval, start, end, func(from int64) (page []Candle, err error) {
+		_, _, result, err := b.GetKLine(symbol, interval, from, klinePageSize)
+		for i := range result {
+			page = append(page, result[i].Candle())
+		}
+		return
+	})
+}
+
+// LinearKlineRange downloads the USDT perpetual candles of symbol opening in [start, end), see KlineRange
+func (b *ByBit) LinearKlineRange(symbol string, interval string, start time.Time, end time.Time) (candles []Candle, gaps []KlineGap, err error) {
+	return b.klineRange(interval, start, end, func(from int64) (page []Candle, err error) {
+		_, _, result, err := b.LinearGetKLine(symbol, interval, from, klinePageSize)
+		for i := range result {
+			page = append(page, result[i].Candle())
+		}
+		return
+	})
+}
+
+func (b *ByBit) klineRange(interval string, start time.Time, end time.Time, fetch func(from int64) ([]Candle, error)) (candles []Candle, gaps []KlineGap, err error) {
+	next, err := klineStep(interval)
+	if err != nil {
+		return
+	}
+	from := start
+	for from.Before(end) {
+		var page []Candle
+		page, err = fetch(from.Unix())
+		if err != nil {
+			return
+		}
+		progressed := false
+		for _, c := range page {
+			// pages may overlap or start before from
+			if c.OpenTime.Before(start) || !c.OpenTime.Before(end) {
+				continue
+			}
+			if n := len(candles); n > 0 && !c.OpenTime.After(candles[n-1].OpenTime) {
+				continue
+			}
+			candles = append(candles, c)
+			progressed = true
+		}
+		if !progressed {
+			break
+		}
+		from = next(candles[len(candles)-1].OpenTime)
+	}
+	gaps = klineGaps(candles, start, end, interval, next)
+	return
+}
+
+// klineGaps reports the candles missing between start, the candles and the
+// last candle closed before min(end, now)
+func klineGaps(candles []Candle, start time.Time, end time.Time, interval string, next func(time.Time) time.Time) (gaps []KlineGap) {
+	gap := func(from time.Time, to time.Time) {
+		g := KlineGap{Start: from, End: to}
+		for t := from; t.Before(to); t = next(t) {
+			g.Missing++
+		}
+		if g.Missing > 0 {
+			gaps = append(gaps, g)
+		}
+	}
+
+	expected, aligned := klineAlign(start, interval, next)
+	if !aligned && len(candles) > 0 {
+		expected = candles[0].OpenTime
+	}
+	for _, c := range candles {
+		gap(expected, c.OpenTime)
+		expected = next(c.OpenTime)
+	}
+
+	// the candles opening after now are not missing, nor is the one in progress
+	until := end
+	if now := time.Now(); now.Before(until) {
+		until = now
+	}
+	last := expected
+	for t := expected; !next(t).After(until); t = next(t) {
+		last = next(t)
+	}
+	if aligned || len(candles) > 0 {
+		gap(expected, last)
+	}
+	return
+}
+
+// klineAlign returns the open time of the first candle at or after start,
+// aligned is false for the weekly and monthly intervals whose alignment is
+// left to the exchange
+func klineAlign(start time.Time, interval string, next func(time.Time) time.Time) (t time.Time, aligned bool) {
+	if interval == "W" || interval == "M" {
+		return start, false
+	}
+	d := next(time.Unix(0, 0)).Sub(time.Unix(0, 0))
+	t = start.Truncate(d).UTC()
+	if t.Before(start) {
+		t = t.Add(d)
+	}
+	return t, true
+}
+
+// klineStep returns the function giving the open time of the candle following t
+func klineStep(interval string) (next func(t time.Time) time.Time, err error) {
+	switch interval {
+	case "1", "3", "5", "15", "30", "60", "120", "240", "360", "720":
+		var minutes int
+		fmt.Sscan(interval, &minutes)
+		d := time.Duration(minutes) * time.Minute
+		next = func(t time.Time) time.Time { return t.Add(d) }
+	case "D":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case "W":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case "M":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		err = fmt.Errorf("bybit: unknown kline interval %q", interval)
+	}
+	return
+}
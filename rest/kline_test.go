@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearKlineRange(t *testing.T) {
+	t0 := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	missing := map[int]bool{250: true, 251: true, 252: true}
+	var requests int
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/public/linear/kline", r.URL.Path)
+		from, _ := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		// 1 minute candles for minutes 0-449, each page overlapping the previous one by a candle
+		var data []string
+		for m := int(from-t0.Unix())/60 - 1; m < 450 && len(data) < limit; m++ {
+			if m < 0 || missing[m] {
+				continue
+			}
+			data = append(data, fmt.Sprintf(`{"symbol":"BTCUSDT","period":"1","open_time":%d,"open":%d,"high":%d,"low":%d,"close":%d,"volume":1,"turnover":%d}`,
+				t0.Unix()+int64(m*60), m, m+1, m-1, m, m))
+		}
+		fmt.Fprintf(w, `{"ret_code":0,"ret_msg":"OK","result":[%s]}`, strings.Join(data, ","))
+	})
+
+	candles, gaps, err := b.LinearKlineRange("BTCUSDT", "1", t0, t0.Add(460*time.Minute))
+	assert.Nil(t, err)
+	assert.Len(t, candles, 447)
+	for i := 1; i < len(candles); i++ {
+		assert.True(t, candles[i].OpenTime.After(candles[i-1].OpenTime))
+	}
+	c := candles[249]
+	assert.Equal(t, "BTCUSDT", c.Symbol)
+	assert.Equal(t, "1", c.Interval)
+	assert.Equal(t, t0.Add(249*time.Minute), c.OpenTime)
+	assert.Equal(t, []string{"249", "250", "248", "249", "1", "249"}, []string{
+		c.Open.String(), c.High.String(), c.Low.String(), c.Close.String(), c.Volume.String(), c.Turnover.String()})
+	assert.Equal(t, []KlineGap{
+		{Start: t0.Add(250 * time.Minute), End: t0.Add(253 * time.Minute), Missing: 3},
+		{Start: t0.Add(450 * time.Minute), End: t0.Add(460 * time.Minute), Missing: 10},
+	}, gaps)
+	assert.Equal(t, 4, requests)
+}
+
+func TestKlineRange_UnknownInterval(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	_, _, err := b.KlineRange("BTCUSD", "2", time.Now().Add(-time.Hour), time.Now())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,158 @@
+package rest
+
+// Side of an order or position
+type Side string
+
+const (
+	SideBuy  Side = "Buy"
+	SideSell Side = "Sell"
+)
+
+// OrderType of an order
+type OrderType string
+
+const (
+	OrderTypeLimit  OrderType = "Limit"
+	OrderTypeMarket OrderType = "Market"
+)
+
+// TimeInForce of an order
+type TimeInForce string
+
+const (
+	GoodTillCancel    TimeInForce = "GoodTillCancel"
+	ImmediateOrCancel TimeInForce = "ImmediateOrCancel"
+	FillOrKill        TimeInForce = "FillOrKill"
+	PostOnly          TimeInForce = "PostOnly"
+)
+
+// TriggerBy is the price type triggering conditional orders, take profit and stop loss
+type TriggerBy string
+
+const (
+	TriggerByLastPrice  TriggerBy = "LastPrice"
+	TriggerByIndexPrice TriggerBy = "IndexPrice"
+	TriggerByMarkPrice  TriggerBy = "MarkPrice"
+)
+
+// PositionIdx identifies the position in the different position modes
+type PositionIdx int
+
+const (
+	PositionIdxOneWay    PositionIdx = 0 // one-way mode position
+	PositionIdxHedgeBuy  PositionIdx = 1 // buy side of hedge mode position
+	PositionIdxHedgeSell PositionIdx = 2 // sell side of hedge mode position
+)
+
+// CreateOrderRequest is an inverse perpetual order, see PlaceOrder
+type CreateOrderRequest struct {
+	Symbol         string      `json:"symbol"`
+	Side           Side        `json:"side"`
+	OrderType      OrderType   `json:"order_type"`
+	Qty            int         `json:"qty"`              // contracts (USD)
+	Price          float64     `json:"price"`            // required for limit orders
+	TimeInForce    TimeInForce `json:"time_in_force"`    // GoodTillCancel by default
+	TakeProfit     float64     `json:"take_profit"`      // optional
+	StopLoss       float64     `json:"stop_loss"`        // optional
+	TpTriggerBy    TriggerBy   `json:"tp_trigger_by"`    // LastPrice by default
+	SlTriggerBy    TriggerBy   `json:"sl_trigger_by"`    // LastPrice by default
+	ReduceOnly     bool        `json:"reduce_only"`      // only reduce the position
+	CloseOnTrigger bool        `json:"close_on_trigger"` // close the position, cancelling other orders if margin is short
+	OrderLinkID    string      `json:"order_link_id"`    // unique user-set id, makes the order safe to retry
+	PositionIdx    PositionIdx `json:"position_idx"`     // required in hedge mode, see SwitchPositionMode
+}
+
+func (r *CreateOrderRequest) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	params["side"] = r.Side
+	params["symbol"] = r.Symbol
+	params["order_type"] = r.OrderType
+	params["qty"] = r.Qty
+	if r.Price > 0 {
+		params["price"] = r.Price
+	}
+	params["time_in_force"] = timeInForceOrDefault(r.TimeInForce)
+	if r.TakeProfit > 0 {
+		params["take_profit"] = r.TakeProfit
+	}
+	if r.StopLoss > 0 {
+		params["stop_loss"] = r.StopLoss
+	}
+	if r.TpTriggerBy != "" {
+		params["tp_trigger_by"] = r.TpTriggerBy
+	}
+	if r.SlTriggerBy != "" {
+		params["sl_trigger_by"] = r.SlTriggerBy
+	}
+	if r.ReduceOnly {
+		params["reduce_only"] = true
+	}
+	if r.CloseOnTrigger {
+		params["close_on_trigger"] = true
+	}
+	if r.OrderLinkID != "" {
+		params["order_link_id"] = r.OrderLinkID
+	}
+	if r.PositionIdx != PositionIdxOneWay {
+		params["position_idx"] = r.PositionIdx
+	}
+	return params
+}
+
+// LinearCreateOrderRequest is a USDT perpetual order, see LinearPlaceOrder
+type LinearCreateOrderRequest struct {
+	Symbol         string      `json:"symbol"`
+	Side           Side        `json:"side"`
+	OrderType      OrderType   `json:"order_type"`
+	Qty            float64     `json:"qty"`              // base currency, e.g. BTC
+	Price          float64     `json:"price"`            // required for limit orders
+	TimeInForce    TimeInForce `json:"time_in_force"`    // GoodTillCancel by default
+	TakeProfit     float64     `json:"take_profit"`      // optional
+	StopLoss       float64     `json:"stop_loss"`        // optional
+	TpTriggerBy    TriggerBy   `json:"tp_trigger_by"`    // LastPrice by default
+	SlTriggerBy    TriggerBy   `json:"sl_trigger_by"`    // LastPrice by default
+	ReduceOnly     bool        `json:"reduce_only"`      // only reduce the position
+	CloseOnTrigger bool        `json:"close_on_trigger"` // close the position, cancelling other orders if margin is short
+	OrderLinkID    string      `json:"order_link_id"`    // unique user-set id, makes the order safe to retry
+	PositionIdx    PositionIdx `json:"position_idx"`     // required in hedge mode
+}
+
+func (r *LinearCreateOrderRequest) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	params["side"] = r.Side
+	params["symbol"] = r.Symbol
+	params["order_type"] = r.OrderType
+	params["qty"] = r.Qty
+	if r.Price > 0 {
+		params["price"] = r.Price
+	}
+	params["time_in_force"] = timeInForceOrDefault(r.TimeInForce)
+	if r.TakeProfit > 0 {
+		params["take_profit"] = r.TakeProfit
+	}
+	if r.StopLoss > 0 {
+		params["stop_loss"] = r.StopLoss
+	}
+	if r.TpTriggerBy != "" {
+		params["tp_trigger_by"] = r.TpTriggerBy
+	}
+	if r.SlTriggerBy != "" {
+		params["sl_trigger_by"] = r.SlTriggerBy
+	}
+	params["reduce_only"] = r.ReduceOnly
+	params["close_on_trigger"] = r.CloseOnTrigger
+	if r.OrderLinkID != "" {
+		params["order_link_id"] = r.OrderLinkID
+	}
+	if r.PositionIdx != PositionIdxOneWay {
+		params["position_idx"] = r.PositionIdx
+	}
+	return params
+}
+
+func timeInForceOrDefault(tif TimeInForce) TimeInForce {
+	if tif == "" {
+		return GoodTillCancel
+	}
+	return tif
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByBit_LinearPlaceOrder(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"abc","symbol":"BTCUSDT","price":35000.5,"qty":0.01,"cum_exec_fee":"0.00026250","position_idx":2}}`))
+	})
+
+	_, _, order, err := b.LinearPlaceOrder(LinearCreateOrderRequest{
+		Symbol:      "BTCUSDT",
+		Side:        SideSell,
+		OrderType:   OrderTypeLimit,
+		Qty:         0.01,
+		Price:       35000.5,
+		TakeProfit:  30000,
+		TpTriggerBy: TriggerByMarkPrice,
+		PositionIdx: PositionIdxHedgeSell,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", order.OrderId)
+	assert.Equal(t, "35000.5", order.Price.String())
+	assert.Equal(t, "0.01", order.Qty.String())
+	assert.Equal(t, "0.0002625", order.CumExecFee.String())
+	assert.Equal(t, "Sell", body["side"])
+	assert.Equal(t, "Limit", body["order_type"])
+	assert.Equal(t, 0.01, body["qty"])
+	assert.Equal(t, 35000.5, body["price"])
+	assert.Equal(t, "GoodTillCancel", body["time_in_force"])
+	assert.Equal(t, "MarkPrice", body["tp_trigger_by"])
+	assert.Equal(t, float64(2), body["position_idx"])
+	assert.Equal(t, false, body["reduce_only"])
+	assert.NotContains(t, body, "stop_loss")
+	assert.NotContains(t, body, "sl_trigger_by")
+}
+
+func TestByBit_CreateOrderWrapper(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"def"}}`))
+	})
+
+	_, _, _, err := b.CreateOrder("Buy", "Market", 0, 100, "ImmediateOrCancel", 0,
+		0, true, false, "", "BTCUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, "Buy", body["side"])
+	assert.Equal(t, "Market", body["order_type"])
+	assert.Equal(t, float64(100), body["qty"])
+	assert.Equal(t, "ImmediateOrCancel", body["time_in_force"])
+	assert.Equal(t, true, body["reduce_only"])
+	assert.NotContains(t, body, "price")
+	assert.NotContains(t, body, "close_on_trigger")
+	assert.NotContains(t, body, "position_idx")
+}
+
+func TestByBit_PlaceOrderHedgeMode(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"ghi","position_idx":1}}`))
+	})
+
+	_, _, _, err := b.PlaceOrder(CreateOrderRequest{
+		Symbol:      "BTCUSD",
+		Side:        SideBuy,
+		OrderType:   OrderTypeMarket,
+		Qty:         100,
+		PositionIdx: PositionIdxHedgeBuy,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1), body["position_idx"])
+}
@@ -0,0 +1,82 @@
+package rest
+
+// PositionMode is one-way (MergedSingle) or hedge (BothSide)
+type PositionMode string
+
+const (
+	PositionModeMergedSingle PositionMode = "MergedSingle"
+	PositionModeBothSide     PositionMode = "BothSide"
+)
+
+// Float64 returns a pointer to v, for the optional fields of the request structs
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// TradingStopRequest sets the take profit, stop loss or trailing stop of a position,
+// nil leaves a price unchanged and 0 cancels it
+type TradingStopRequest struct {
+	Symbol            string      `json:"symbol"`
+	Side              Side        `json:"side"` // linear only, side of the position
+	TakeProfit        *float64    `json:"take_profit"`
+	StopLoss          *float64    `json:"stop_loss"`
+	TrailingStop      *float64    `json:"trailing_stop"`       // distance from the market price
+	NewTrailingActive float64     `json:"new_trailing_active"` // inverse only, trailing stop activation price
+	TpTriggerBy       TriggerBy   `json:"tp_trigger_by"`
+	SlTriggerBy       TriggerBy   `json:"sl_trigger_by"`
+	TpSize            float64     `json:"tp_size"`      // partial tp/sl mode only
+	SlSize            float64     `json:"sl_size"`      // partial tp/sl mode only
+	PositionIdx       PositionIdx `json:"position_idx"` // linear only, required in hedge mode
+}
+
+func (r *TradingStopRequest) params(linear bool) map[string]interface{} {
+	params := map[string]interface{}{}
+	params["symbol"] = r.Symbol
+	if linear {
+		params["side"] = r.Side
+		if r.PositionIdx != PositionIdxOneWay {
+			params["position_idx"] = r.PositionIdx
+		}
+	} else if r.NewTrailingActive > 0 {
+		params["new_trailing_active"] = r.NewTrailingActive
+	}
+	if r.TakeProfit != nil {
+		params["take_profit"] = *r.TakeProfit
+	}
+	if r.StopLoss != nil {
+		params["stop_loss"] = *r.StopLoss
+	}
+	if r.TrailingStop != nil {
+		params["trailing_stop"] = *r.TrailingStop
+	}
+	if r.TpTriggerBy != "" {
+		params["tp_trigger_by"] = r.TpTriggerBy
+	}
+	if r.SlTriggerBy != "" {
+		params["sl_trigger_by"] = r.SlTriggerBy
+	}
+	if r.TpSize > 0 {
+		params["tp_size"] = r.TpSize
+	}
+	if r.SlSize > 0 {
+		params["sl_size"] = r.SlSize
+	}
+	return params
+}
+
+// SwitchIsolatedRequest switches a position between cross and isolated margin
+type SwitchIsolatedRequest struct {
+	Symbol       string  `json:"symbol"`
+	IsIsolated   bool    `json:"is_isolated"`
+	BuyLeverage  float64 `json:"buy_leverage"`
+	SellLeverage float64 `json:"sell_leverage"`
+}
+
+func (r *SwitchIsolatedRequest) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	params["symbol"] = r.Symbol
+	params["is_isolated"] = r.IsIsolated
+	params["buy_leverage"] = r.BuyLeverage
+	params["sell_leverage"] = r.SellLeverage
+	return params
+}
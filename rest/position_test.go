@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByBit_LinearSetTradingStop(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/private/linear/position/trading-stop", r.URL.Path)
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":null}`))
+	})
+
+	_, _, err := b.LinearSetTradingStop(TradingStopRequest{
+		Symbol:      "BTCUSDT",
+		Side:        SideBuy,
+		TakeProfit:  Float64(40000),
+		StopLoss:    Float64(0),
+		SlTriggerBy: TriggerByMarkPrice,
+		PositionIdx: PositionIdxHedgeBuy,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(40000), body["take_profit"])
+	// 0 cancels the stop loss, nil leaves the trailing stop unchanged
+	assert.Equal(t, float64(0), body["stop_loss"])
+	assert.NotContains(t, body, "trailing_stop")
+	assert.Equal(t, "MarkPrice", body["sl_trigger_by"])
+	assert.Equal(t, float64(1), body["position_idx"])
+}
+
+func TestByBit_SwitchPositionMode(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":null}`))
+	})
+
+	_, _, err := b.SwitchPositionMode("BTCUSD", PositionModeBothSide)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(3), body["mode"])
+
+	_, _, err = b.LinearSwitchPositionMode("BTCUSDT", PositionModeBothSide)
+	assert.Nil(t, err)
+	assert.Equal(t, "BothSide", body["mode"])
+}
+
+func TestByBit_RiskLimits(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/public/risk-limit/list":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[{"id":1,"coin":"BTC","limit":150,"maintain_margin":"0.5","starting_margin":"1","section":["1","2","3"],"is_lowest_risk":1,"created_at":"2019-06-26T03:27:07.000Z","updated_at":"2021-09-14T03:33:47.000Z","max_leverage":"100"}]}`))
+		case "/private/linear/position/set-risk":
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"risk_id":2,"position_idx":1}}`))
+		}
+	})
+
+	_, _, limits, err := b.GetRiskLimits("BTCUSD")
+	assert.Nil(t, err)
+	if assert.Len(t, limits, 1) {
+		assert.Equal(t, "0.5", limits[0].MaintainMargin.String())
+		assert.Equal(t, "100", limits[0].MaxLeverage.String())
+	}
+
+	_, _, result, err := b.LinearSetRiskLimit("BTCUSDT", SideBuy, PositionIdxHedgeBuy, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, SetRiskLimitResult{RiskID: 2, PositionIdx: 1}, result)
+}
@@ -0,0 +1,253 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by a fail-fast RateLimiter when an endpoint has no quota left
+var ErrRateLimited = errors.New("bybit: client side rate limit reached")
+
+// RateLimitState is the last quota reported by the exchange for an endpoint
+type RateLimitState struct {
+	Endpoint  string    `json:"endpoint"`
+	Limit     int       `json:"limit"`     // rate_limit / X-Bapi-Limit
+	Remaining int       `json:"remaining"` // rate_limit_status / X-Bapi-Limit-Status
+	ResetAt   time.Time `json:"reset_at"`  // rate_limit_reset_ms / X-Bapi-Limit-Reset-Timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Exhausted reports whether the quota is used up and not yet reset at now
+func (s RateLimitState) Exhausted(now time.Time) bool {
+	return s.Limit > 0 && s.Remaining <= 0 && now.Before(s.ResetAt)
+}
+
+type rateLimitTracker struct {
+	mu     sync.RWMutex
+	states map[string]RateLimitState // key: endpoint
+}
+
+func newRateLimitTracker() *rateLimitTracker {
+	return &rateLimitTracker{
+		states: make(map[string]RateLimitState),
+	}
+}
+
+// observe records the quota carried by the response headers or, failing that, the body
+func (t *rateLimitTracker) observe(endpoint string, header http.Header, r *retStatus) {
+	s := RateLimitState{
+		Endpoint:  endpoint,
+		UpdatedAt: time.Now(),
+	}
+	if v := header.Get("X-Bapi-Limit"); v != "" {
+		s.Limit, _ = strconv.Atoi(v)
+		s.Remaining, _ = strconv.Atoi(header.Get("X-Bapi-Limit-Status"))
+		resetMs, _ := strconv.ParseInt(header.Get("X-Bapi-Limit-Reset-Timestamp"), 10, 64)
+		s.ResetAt = time.Unix(0, resetMs*int64(time.Millisecond))
+	} else if r != nil && r.RateLimit > 0 {
+		s.Limit = r.RateLimit
+		s.Remaining = r.RateLimitStatus
+		s.ResetAt = time.Unix(0, r.RateLimitResetMs*int64(time.Millisecond))
+	} else {
+		return
+	}
+
+	t.mu.Lock()
+	t.states[endpoint] = s
+	t.mu.Unlock()
+}
+
+func (t *rateLimitTracker) get(endpoint string) (s RateLimitState, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	s, ok = t.states[endpoint]
+	return
+}
+
+func (t *rateLimitTracker) all() (states []RateLimitState) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, s := range t.states {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Endpoint < states[j].Endpoint
+	})
+	return
+}
+
+// RateLimitState returns the last quota the exchange reported for endpoint, e.g. "private/linear/order/create"
+func (b *ByBit) RateLimitState(endpoint string) (state RateLimitState, ok bool) {
+	return b.rateLimits.get(endpoint)
+}
+
+// RateLimitStates returns the last quota of every endpoint called so far, sorted by endpoint
+func (b *ByBit) RateLimitStates() []RateLimitState {
+	return b.rateLimits.all()
+}
+
+// SetRateLimiter enables client side rate limiting, nil disables it
+func (b *ByBit) SetRateLimiter(limiter *RateLimiter) {
+	b.limiter = limiter
+}
+
+type RateLimitMode int
+
+const (
+	RateLimitBlock    RateLimitMode = iota // wait until a token is available
+	RateLimitFailFast                      // return ErrRateLimited immediately
+)
+
+// RateLimiter is a per-endpoint token bucket applied before each request is sent.
+// It also honours an exhausted quota reported by the exchange until its reset time.
+type RateLimiter struct {
+	mode    RateLimitMode
+	rate    float64 // tokens per second
+	burst   int
+	mu      sync.Mutex
+	limits  map[string]endpointLimit
+	buckets map[string]*tokenBucket // key: endpoint
+}
+
+type endpointLimit struct {
+	rate  float64
+	burst int
+}
+
+// NewRateLimiter creates a limiter allowing rate requests per second with bursts of
+// burst requests on each endpoint. Bybit's default private limit is 100 requests per minute.
+func NewRateLimiter(rate float64, burst int, mode RateLimitMode) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		mode:    mode,
+		rate:    rate,
+		burst:   burst,
+		limits:  make(map[string]endpointLimit),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// SetEndpointLimit overrides the default rate and burst for one endpoint
+func (l *RateLimiter) SetEndpointLimit(endpoint string, rate float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limits[endpoint] = endpointLimit{rate: rate, burst: burst}
+	delete(l.buckets, endpoint)
+}
+
+func (l *RateLimiter) bucket(endpoint string) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tb, ok := l.buckets[endpoint]
+	if !ok {
+		limit, ok := l.limits[endpoint]
+		if !ok {
+			limit = endpointLimit{rate: l.rate, burst: l.burst}
+		}
+		tb = &tokenBucket{
+			rate:   limit.rate,
+			burst:  float64(limit.burst),
+			tokens: float64(limit.burst),
+			last:   time.Now(),
+		}
+		l.buckets[endpoint] = tb
+	}
+	return tb
+}
+
+// wait takes a token for endpoint, blocking or failing according to the limiter mode
+func (l *RateLimiter) wait(ctx context.Context, endpoint string, state RateLimitState, known bool) error {
+	failFast := l.mode == RateLimitFailFast
+	if now := time.Now(); known && state.Exhausted(now) {
+		if failFast {
+			return ErrRateLimited
+		}
+		if err := sleepContext(ctx, state.ResetAt.Sub(now)); err != nil {
+			return err
+		}
+	}
+	tb := l.bucket(endpoint)
+	delay, ok := tb.reserve(time.Now(), failFast)
+	if !ok {
+		return ErrRateLimited
+	}
+	if err := sleepContext(ctx, delay); err != nil {
+		// the request is not sent, its token goes to the next one
+		tb.release()
+		return err
+	}
+	return nil
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token and returns how long to wait before using it.
+// With onlyIfAvailable nothing is taken from an empty bucket and ok is false.
+func (tb *tokenBucket) reserve(now time.Time, onlyIfAvailable bool) (delay time.Duration, ok bool) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if elapsed := now.Sub(tb.last).Seconds(); elapsed > 0 {
+		tb.tokens += elapsed * tb.rate
+		if tb.tokens > tb.burst {
+			tb.tokens = tb.burst
+		}
+		tb.last = now
+	}
+	if tb.tokens >= 1 {
+		tb.tokens--
+		return 0, true
+	}
+	if onlyIfAvailable || tb.rate <= 0 {
+		return 0, false
+	}
+	tb.tokens--
+	return time.Duration(-tb.tokens / tb.rate * float64(time.Second)), true
+}
+
+// release returns a reserved token which was not used
+func (tb *tokenBucket) release() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.tokens++
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
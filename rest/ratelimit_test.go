@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByBit_RateLimitStateFromBody(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[],"time_now":"1643076385.967696","rate_limit_status":97,"rate_limit_reset_ms":1643076385963,"rate_limit":100}`))
+	})
+
+	_, _, _, err := b.LinearGetPositions()
+	assert.Nil(t, err)
+
+	state, ok := b.RateLimitState("private/linear/position/list")
+	if assert.True(t, ok) {
+		assert.Equal(t, 100, state.Limit)
+		assert.Equal(t, 97, state.Remaining)
+		assert.Equal(t, int64(1643076385963), state.ResetAt.UnixNano()/1e6)
+	}
+	_, ok = b.RateLimitState("private/linear/order/create")
+	assert.False(t, ok)
+}
+
+func TestByBit_RateLimitStateFromHeader(t *testing.T) {
+	reset := time.Now().Add(time.Minute).UnixNano() / 1e6
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Bapi-Limit", "50")
+		w.Header().Set("X-Bapi-Limit-Status", "0")
+		w.Header().Set("X-Bapi-Limit-Reset-Timestamp", strconv.FormatInt(reset, 10))
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[],"rate_limit_status":97,"rate_limit":100}`))
+	})
+
+	_, _, _, err := b.LinearGetPositions()
+	assert.Nil(t, err)
+
+	states := b.RateLimitStates()
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, 50, states[0].Limit)
+		assert.Equal(t, 0, states[0].Remaining)
+		assert.True(t, states[0].Exhausted(time.Now()))
+	}
+
+	// the exchange reported no quota left, a fail-fast limiter must not send the request
+	b.SetRateLimiter(NewRateLimiter(100, 10, RateLimitFailFast))
+	_, _, _, err = b.LinearGetPositions()
+	assert.True(t, errors.Is(err, ErrRateLimited))
+}
+
+func TestRateLimiter_FailFast(t *testing.T) {
+	var calls int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[]}`))
+	})
+	b.SetRateLimiter(NewRateLimiter(0.001, 2, RateLimitFailFast))
+
+	for i := 0; i < 2; i++ {
+		_, _, _, err := b.GetTickers()
+		assert.Nil(t, err)
+	}
+	_, _, _, err := b.GetTickers()
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// buckets are per endpoint
+	_, _, _, err = b.GetSymbols()
+	assert.Nil(t, err)
+}
+
+func TestRateLimiter_Block(t *testing.T) {
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[]}`))
+	})
+	limiter := NewRateLimiter(20, 1, RateLimitBlock)
+	b.SetRateLimiter(limiter)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, _, _, err := b.GetTickers()
+		assert.Nil(t, err)
+	}
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(90*time.Millisecond))
+
+	limiter.SetEndpointLimit("v2/public/tickers", 0.001, 1)
+	_, _, _, err := b.GetTickers()
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, _, err = b.WithContext(ctx).GetTickers()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+}
+
+func TestRateLimiter_CancelReleasesToken(t *testing.T) {
+	limiter := NewRateLimiter(10, 1, RateLimitBlock)
+	assert.Nil(t, limiter.wait(context.Background(), "v2/public/tickers", RateLimitState{}, false))
+
+	// cancelled waits do not use up the bucket
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := limiter.wait(ctx, "v2/public/tickers", RateLimitState{}, false)
+		cancel()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+	}
+
+	// the next token is due 100ms after the first one, not 600ms
+	start := time.Now()
+	assert.Nil(t, limiter.wait(context.Background(), "v2/public/tickers", RateLimitState{}, false))
+	assert.Less(t, int64(time.Since(start)), int64(100*time.Millisecond))
+}
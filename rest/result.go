@@ -2,6 +2,7 @@ package rest
 
 import (
 	sjson "encoding/json"
+	"github.com/wilcosheh/bybit-api/decimal"
 	"time"
 )
 
@@ -23,8 +24,8 @@ type ResultStringArrayResponse struct {
 }
 
 type Item struct {
-	Price float64 `json:"price,string"`
-	Size  float64 `json:"size"`
+	Price decimal.Decimal `json:"price"`
+	Size  decimal.Decimal `json:"size"`
 }
 
 type OrderBook struct {
@@ -34,10 +35,10 @@ type OrderBook struct {
 }
 
 type RawItem struct {
-	Symbol string  `json:"symbol"`
-	Price  float64 `json:"price,string"`
-	Size   float64 `json:"size"`
-	Side   string  `json:"side"` // Buy/Sell
+	Symbol string          `json:"symbol"`
+	Price  decimal.Decimal `json:"price"`
+	Size   decimal.Decimal `json:"size"`
+	Side   string          `json:"side"` // Buy/Sell
 }
 
 type GetOrderBookResult struct {
@@ -46,15 +47,15 @@ type GetOrderBookResult struct {
 }
 
 type OHLC struct {
-	Symbol   string  `json:"symbol"`
-	Interval string  `json:"interval"`
-	OpenTime int64   `json:"open_time"`
-	Open     float64 `json:"open,string"`
-	High     float64 `json:"high,string"`
-	Low      float64 `json:"low,string"`
-	Close    float64 `json:"close,string"`
-	Volume   float64 `json:"volume,string"`
-	Turnover float64 `json:"turnover,string"`
+	Symbol   string          `json:"symbol"`
+	Interval string          `json:"interval"`
+	OpenTime int64           `json:"open_time"`
+	Open     decimal.Decimal `json:"open"`
+	High     decimal.Decimal `json:"high"`
+	Low      decimal.Decimal `json:"low"`
+	Close    decimal.Decimal `json:"close"`
+	Volume   decimal.Decimal `json:"volume"`
+	Turnover decimal.Decimal `json:"turnover"`
 }
 
 type GetKlineResult struct {
@@ -63,15 +64,15 @@ type GetKlineResult struct {
 }
 
 type OHLCLinear struct {
-	Symbol   string  `json:"symbol"`
-	Period   string  `json:"period"`
-	OpenTime int64   `json:"open_time"`
-	Open     float64 `json:"open"`
-	High     float64 `json:"high"`
-	Low      float64 `json:"low"`
-	Close    float64 `json:"close"`
-	Volume   float64 `json:"volume"`
-	Turnover float64 `json:"turnover"`
+	Symbol   string          `json:"symbol"`
+	Period   string          `json:"period"`
+	OpenTime int64           `json:"open_time"`
+	Open     decimal.Decimal `json:"open"`
+	High     decimal.Decimal `json:"high"`
+	Low      decimal.Decimal `json:"low"`
+	Close    decimal.Decimal `json:"close"`
+	Volume   decimal.Decimal `json:"volume"`
+	Turnover decimal.Decimal `json:"turnover"`
 }
 
 type GetLinearKlineResult struct {
@@ -80,9 +81,9 @@ type GetLinearKlineResult struct {
 }
 
 type OpenInterest struct {
-	Symbol       string       `json:"symbol"`
-	OpenInterest sjson.Number `json:"open_interest"`
-	Timestamp    sjson.Number `json:"timestamp"`
+	Symbol       string          `json:"symbol"`
+	OpenInterest decimal.Decimal `json:"open_interest"`
+	Timestamp    sjson.Number    `json:"timestamp"`
 }
 
 type GetOpenInterestResult struct {
@@ -91,10 +92,10 @@ type GetOpenInterestResult struct {
 }
 
 type AccountRatio struct {
-	Symbol    string       `json:"symbol"`
-	BuyRatio  sjson.Number `json:"buy_ratio"`
-	SellRatio sjson.Number `json:"sell_ratio"`
-	Timestamp sjson.Number `json:"timestamp"`
+	Symbol    string          `json:"symbol"`
+	BuyRatio  decimal.Decimal `json:"buy_ratio"`
+	SellRatio decimal.Decimal `json:"sell_ratio"`
+	Timestamp sjson.Number    `json:"timestamp"`
 }
 
 type GetAccountRatioResult struct {
@@ -103,29 +104,29 @@ type GetAccountRatioResult struct {
 }
 
 type Ticker struct {
-	Symbol               string       `json:"symbol"`
-	BidPrice             sjson.Number `json:"bid_price"`
-	AskPrice             sjson.Number `json:"ask_price"`
-	LastPrice            float64      `json:"last_price,string"`
-	LastTickDirection    string       `json:"last_tick_direction"`
-	PrevPrice24H         float64      `json:"prev_price_24h,string"`
-	Price24HPcnt         float64      `json:"price_24h_pcnt,string"`
-	HighPrice24H         float64      `json:"high_price_24h,string"`
-	LowPrice24H          float64      `json:"low_price_24h,string"`
-	PrevPrice1H          float64      `json:"prev_price_1h,string"`
-	Price1HPcnt          float64      `json:"price_1h_pcnt,string"`
-	MarkPrice            float64      `json:"mark_price,string"`
-	IndexPrice           float64      `json:"index_price,string"`
-	OpenInterest         float64      `json:"open_interest"`
-	OpenValue            float64      `json:"open_value,string"`
-	TotalTurnover        float64      `json:"total_turnover,string"`
-	Turnover24H          float64      `json:"turnover_24h,string"`
-	TotalVolume          float64      `json:"total_volume"`
-	Volume24H            float64      `json:"volume_24h"`
-	FundingRate          float64      `json:"funding_rate,string"`
-	PredictedFundingRate float64      `json:"predicted_funding_rate,string"`
-	NextFundingTime      string       `json:"next_funding_time"` // string because can be empty, parse it with "2006-01-02T15:04:05Z07:00"
-	CountdownHour        int          `json:"countdown_hour"`
+	Symbol               string          `json:"symbol"`
+	BidPrice             decimal.Decimal `json:"bid_price"`
+	AskPrice             decimal.Decimal `json:"ask_price"`
+	LastPrice            decimal.Decimal `json:"last_price"`
+	LastTickDirection    string          `json:"last_tick_direction"`
+	PrevPrice24H         decimal.Decimal `json:"prev_price_24h"`
+	Price24HPcnt         decimal.Decimal `json:"price_24h_pcnt"`
+	HighPrice24H         decimal.Decimal `json:"high_price_24h"`
+	LowPrice24H          decimal.Decimal `json:"low_price_24h"`
+	PrevPrice1H          decimal.Decimal `json:"prev_price_1h"`
+	Price1HPcnt          decimal.Decimal `json:"price_1h_pcnt"`
+	MarkPrice            decimal.Decimal `json:"mark_price"`
+	IndexPrice           decimal.Decimal `json:"index_price"`
+	OpenInterest         decimal.Decimal `json:"open_interest"`
+	OpenValue            decimal.Decimal `json:"open_value"`
+	TotalTurnover        decimal.Decimal `json:"total_turnover"`
+	Turnover24H          decimal.Decimal `json:"turnover_24h"`
+	TotalVolume          decimal.Decimal `json:"total_volume"`
+	Volume24H            decimal.Decimal `json:"volume_24h"`
+	FundingRate          decimal.Decimal `json:"funding_rate"`
+	PredictedFundingRate decimal.Decimal `json:"predicted_funding_rate"`
+	NextFundingTime      string          `json:"next_funding_time"` // string because can be empty, parse it with "2006-01-02T15:04:05Z07:00"
+	CountdownHour        int             `json:"countdown_hour"`
 }
 
 type GetTickersResult struct {
@@ -138,12 +139,12 @@ type GetTickersResult struct {
 }
 
 type TradingRecord struct {
-	ID     int       `json:"id"`
-	Symbol string    `json:"symbol"`
-	Price  float64   `json:"price"`
-	Qty    int       `json:"qty"`
-	Side   string    `json:"side"`
-	Time   time.Time `json:"time"`
+	ID     int             `json:"id"`
+	Symbol string          `json:"symbol"`
+	Price  decimal.Decimal `json:"price"`
+	Qty    decimal.Decimal `json:"qty"`
+	Side   string          `json:"side"`
+	Time   time.Time       `json:"time"`
 }
 
 type GetTradingRecordsResult struct {
@@ -156,33 +157,33 @@ type GetTradingRecordsResult struct {
 }
 
 type LeverageFilter struct {
-	MinLeverage  int     `json:"min_leverage"`
-	MaxLeverage  int     `json:"max_leverage"`
-	LeverageStep float64 `json:"leverage_step,string"`
+	MinLeverage  int             `json:"min_leverage"`
+	MaxLeverage  int             `json:"max_leverage"`
+	LeverageStep decimal.Decimal `json:"leverage_step"`
 }
 
 type PriceFilter struct {
-	MinPrice float64 `json:"min_price,string"`
-	MaxPrice float64 `json:"max_price,string"`
-	TickSize float64 `json:"tick_size,string"`
+	MinPrice decimal.Decimal `json:"min_price"`
+	MaxPrice decimal.Decimal `json:"max_price"`
+	TickSize decimal.Decimal `json:"tick_size"`
 }
 
 type LotSizeFilter struct {
-	MaxTradingQty int `json:"max_trading_qty"`
-	MinTradingQty int `json:"min_trading_qty"`
-	QtyStep       int `json:"qty_step"`
+	MaxTradingQty decimal.Decimal `json:"max_trading_qty"`
+	MinTradingQty decimal.Decimal `json:"min_trading_qty"`
+	QtyStep       decimal.Decimal `json:"qty_step"`
 }
 
 type SymbolInfo struct {
-	Name           string         `json:"name"`
-	BaseCurrency   string         `json:"base_currency"`
-	QuoteCurrency  string         `json:"quote_currency"`
-	PriceScale     int            `json:"price_scale"`
-	TakerFee       float64        `json:"taker_fee,string"`
-	MakerFee       float64        `json:"maker_fee,string"`
-	LeverageFilter LeverageFilter `json:"leverage_filter"`
-	PriceFilter    PriceFilter    `json:"price_filter"`
-	LotSizeFilter  LotSizeFilter  `json:"lot_size_filter"`
+	Name           string          `json:"name"`
+	BaseCurrency   string          `json:"base_currency"`
+	QuoteCurrency  string          `json:"quote_currency"`
+	PriceScale     int             `json:"price_scale"`
+	TakerFee       decimal.Decimal `json:"taker_fee"`
+	MakerFee       decimal.Decimal `json:"maker_fee"`
+	LeverageFilter LeverageFilter  `json:"leverage_filter"`
+	PriceFilter    PriceFilter     `json:"price_filter"`
+	LotSizeFilter  LotSizeFilter   `json:"lot_size_filter"`
 }
 
 type GetSymbolsResult struct {
@@ -195,19 +196,19 @@ type GetSymbolsResult struct {
 }
 
 type Balance struct {
-	Equity           float64 `json:"equity"`
-	AvailableBalance float64 `json:"available_balance"`
-	UsedMargin       float64 `json:"used_margin"`
-	OrderMargin      float64 `json:"order_margin"`
-	PositionMargin   float64 `json:"position_margin"`
-	OccClosingFee    float64 `json:"occ_closing_fee"`
-	OccFundingFee    float64 `json:"occ_funding_fee"`
-	WalletBalance    float64 `json:"wallet_balance"`
-	RealisedPnl      float64 `json:"realised_pnl"`
-	UnrealisedPnl    float64 `json:"unrealised_pnl"`
-	CumRealisedPnl   float64 `json:"cum_realised_pnl"`
-	GivenCash        float64 `json:"given_cash"`
-	ServiceCash      float64 `json:"service_cash"`
+	Equity           decimal.Decimal `json:"equity"`
+	AvailableBalance decimal.Decimal `json:"available_balance"`
+	UsedMargin       decimal.Decimal `json:"used_margin"`
+	OrderMargin      decimal.Decimal `json:"order_margin"`
+	PositionMargin   decimal.Decimal `json:"position_margin"`
+	OccClosingFee    decimal.Decimal `json:"occ_closing_fee"`
+	OccFundingFee    decimal.Decimal `json:"occ_funding_fee"`
+	WalletBalance    decimal.Decimal `json:"wallet_balance"`
+	RealisedPnl      decimal.Decimal `json:"realised_pnl"`
+	UnrealisedPnl    decimal.Decimal `json:"unrealised_pnl"`
+	CumRealisedPnl   decimal.Decimal `json:"cum_realised_pnl"`
+	GivenCash        decimal.Decimal `json:"given_cash"`
+	ServiceCash      decimal.Decimal `json:"service_cash"`
 }
 
 type GetBalanceResult struct {
@@ -231,65 +232,65 @@ type GetBalanceResultData struct {
 }
 
 type Position struct {
-	Id                  int       `json:"id"`
-	UserId              int       `json:"user_id"`
-	RiskId              int       `json:"risk_id"`
-	Symbol              string    `json:"symbol"`
-	Size                float64   `json:"size"`
-	Side                string    `json:"side"`
-	EntryPrice          float64   `json:"entry_price,string"`
-	LiqPrice            float64   `json:"liq_price,string"`
-	BustPrice           float64   `json:"bust_price,string"`
-	TakeProfit          float64   `json:"take_profit,string"`
-	StopLoss            float64   `json:"stop_loss,string"`
-	TrailingStop        float64   `json:"trailing_stop,string"`
-	PositionValue       float64   `json:"position_value,string"`
-	Leverage            float64   `json:"leverage,string"`
-	PositionStatus      string    `json:"position_status"`
-	AutoAddMargin       float64   `json:"auto_add_margin"`
-	OrderMargin         float64   `json:"order_margin,string"`
-	PositionMargin      float64   `json:"position_margin,string"`
-	OccClosingFee       float64   `json:"occ_closing_fee,string"`
-	OccFundingFee       float64   `json:"occ_funding_fee,string"`
-	WalletBalance       float64   `json:"wallet_balance,string"`
-	CumRealisedPnl      float64   `json:"cum_realised_pnl,string"`
-	CumCommission       float64   `json:"cum_commission,string"`
-	RealisedPnl         float64   `json:"realised_pnl,string"`
-	DeleverageIndicator float64   `json:"deleverage_indicator"`
-	OcCalcData          string    `json:"oc_calc_data"`
-	CrossSeq            float64   `json:"cross_seq"`
-	PositionSeq         float64   `json:"position_seq"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
-	UnrealisedPnl       float64   `json:"unrealised_pnl"`
+	Id                  int             `json:"id"`
+	UserId              int             `json:"user_id"`
+	RiskId              int             `json:"risk_id"`
+	Symbol              string          `json:"symbol"`
+	Size                decimal.Decimal `json:"size"`
+	Side                string          `json:"side"`
+	EntryPrice          decimal.Decimal `json:"entry_price"`
+	LiqPrice            decimal.Decimal `json:"liq_price"`
+	BustPrice           decimal.Decimal `json:"bust_price"`
+	TakeProfit          decimal.Decimal `json:"take_profit"`
+	StopLoss            decimal.Decimal `json:"stop_loss"`
+	TrailingStop        decimal.Decimal `json:"trailing_stop"`
+	PositionValue       decimal.Decimal `json:"position_value"`
+	Leverage            decimal.Decimal `json:"leverage"`
+	PositionStatus      string          `json:"position_status"`
+	AutoAddMargin       float64         `json:"auto_add_margin"`
+	OrderMargin         decimal.Decimal `json:"order_margin"`
+	PositionMargin      decimal.Decimal `json:"position_margin"`
+	OccClosingFee       decimal.Decimal `json:"occ_closing_fee"`
+	OccFundingFee       decimal.Decimal `json:"occ_funding_fee"`
+	WalletBalance       decimal.Decimal `json:"wallet_balance"`
+	CumRealisedPnl      decimal.Decimal `json:"cum_realised_pnl"`
+	CumCommission       decimal.Decimal `json:"cum_commission"`
+	RealisedPnl         decimal.Decimal `json:"realised_pnl"`
+	DeleverageIndicator float64         `json:"deleverage_indicator"`
+	OcCalcData          string          `json:"oc_calc_data"`
+	CrossSeq            float64         `json:"cross_seq"`
+	PositionSeq         float64         `json:"position_seq"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+	UnrealisedPnl       decimal.Decimal `json:"unrealised_pnl"`
 }
 
 type LinearPosition struct {
-	UserID              int     `json:"user_id"`
-	Symbol              string  `json:"symbol"`
-	Side                string  `json:"side"`
-	Size                float64 `json:"size"`
-	PositionValue       float64 `json:"position_value"`
-	EntryPrice          float64 `json:"entry_price"`
-	LiqPrice            float64 `json:"liq_price"`
-	BustPrice           float64 `json:"bust_price"`
-	Leverage            float64 `json:"leverage"`
-	AutoAddMargin       float64 `json:"auto_add_margin"`
-	IsIsolated          bool    `json:"is_isolated"`
-	PositionMargin      float64 `json:"position_margin"`
-	OccClosingFee       float64 `json:"occ_closing_fee"`
-	RealisedPnl         float64 `json:"realised_pnl"`
-	CumRealisedPnl      float64 `json:"cum_realised_pnl"`
-	FreeQty             float64 `json:"free_qty"`
-	TpSlMode            string  `json:"tp_sl_mode"`
-	UnrealisedPnl       float64 `json:"unrealised_pnl"`
-	DeleverageIndicator float64 `json:"deleverage_indicator"`
-	RiskID              int     `json:"risk_id"`
-	StopLoss            float64 `json:"stop_loss"`
-	TakeProfit          float64 `json:"take_profit"`
-	TrailingStop        float64 `json:"trailing_stop"`
-	PositionIdx         int     `json:"position_idx"`
-	Mode                string  `json:"mode"`
+	UserID              int             `json:"user_id"`
+	Symbol              string          `json:"symbol"`
+	Side                string          `json:"side"`
+	Size                decimal.Decimal `json:"size"`
+	PositionValue       decimal.Decimal `json:"position_value"`
+	EntryPrice          decimal.Decimal `json:"entry_price"`
+	LiqPrice            decimal.Decimal `json:"liq_price"`
+	BustPrice           decimal.Decimal `json:"bust_price"`
+	Leverage            decimal.Decimal `json:"leverage"`
+	AutoAddMargin       float64         `json:"auto_add_margin"`
+	IsIsolated          bool            `json:"is_isolated"`
+	PositionMargin      decimal.Decimal `json:"position_margin"`
+	OccClosingFee       decimal.Decimal `json:"occ_closing_fee"`
+	RealisedPnl         decimal.Decimal `json:"realised_pnl"`
+	CumRealisedPnl      decimal.Decimal `json:"cum_realised_pnl"`
+	FreeQty             decimal.Decimal `json:"free_qty"`
+	TpSlMode            string          `json:"tp_sl_mode"`
+	UnrealisedPnl       decimal.Decimal `json:"unrealised_pnl"`
+	DeleverageIndicator float64         `json:"deleverage_indicator"`
+	RiskID              int             `json:"risk_id"`
+	StopLoss            decimal.Decimal `json:"stop_loss"`
+	TakeProfit          decimal.Decimal `json:"take_profit"`
+	TrailingStop        decimal.Decimal `json:"trailing_stop"`
+	PositionIdx         int             `json:"position_idx"`
+	Mode                string          `json:"mode"`
 }
 
 type PositionResponse struct {
@@ -323,25 +324,35 @@ type LinearPositionData struct {
 }
 
 type Order struct {
-	UserId        int          `json:"user_id"`
-	OrderId       string       `json:"order_id"`
-	Symbol        string       `json:"symbol"`
-	Side          string       `json:"side"`
-	OrderType     string       `json:"order_type"`
-	Price         sjson.Number `json:"price"`
-	Qty           sjson.Number `json:"qty"`
-	TimeInForce   string       `json:"time_in_force"`
-	OrderStatus   string       `json:"order_status"`
-	LastExecTime  sjson.Number `json:"last_exec_time"`
-	LastExecPrice sjson.Number `json:"last_exec_price"`
-	LeavesQty     sjson.Number `json:"leaves_qty"`
-	CumExecQty    sjson.Number `json:"cum_exec_qty"`
-	CumExecValue  sjson.Number `json:"cum_exec_value"`
-	CumExecFee    sjson.Number `json:"cum_exec_fee"`
-	RejectReason  string       `json:"reject_reason"`
-	OrderLinkID   string       `json:"order_link_id"`
-	CreatedAt     time.Time    `json:"created_at"`
-	UpdatedAt     time.Time    `json:"updated_at"`
+	UserId        int             `json:"user_id"`
+	OrderId       string          `json:"order_id"`
+	Symbol        string          `json:"symbol"`
+	Side          string          `json:"side"`
+	OrderType     string          `json:"order_type"`
+	Price         decimal.Decimal `json:"price"`
+	Qty           decimal.Decimal `json:"qty"`
+	TimeInForce   string          `json:"time_in_force"`
+	OrderStatus   string          `json:"order_status"`
+	LastExecTime  sjson.Number    `json:"last_exec_time"`
+	LastExecPrice decimal.Decimal `json:"last_exec_price"`
+	LeavesQty     decimal.Decimal `json:"leaves_qty"`
+	CumExecQty    decimal.Decimal `json:"cum_exec_qty"`
+	CumExecValue  decimal.Decimal `json:"cum_exec_value"`
+	CumExecFee    decimal.Decimal `json:"cum_exec_fee"`
+	RejectReason  string          `json:"reject_reason"`
+	OrderLinkID   string          `json:"order_link_id"`
+	CreatedAt     time.Time       `json:"created_at"`   // inverse
+	UpdatedAt     time.Time       `json:"updated_at"`   // inverse
+	CreatedTime   time.Time       `json:"created_time"` // linear
+	UpdatedTime   time.Time       `json:"updated_time"` // linear
+}
+
+// Created returns CreatedAt or, for linear orders, CreatedTime
+func (o *Order) Created() time.Time {
+	if o.CreatedAt.IsZero() {
+		return o.CreatedTime
+	}
+	return o.CreatedAt
 }
 
 type OrderListResponse struct {
@@ -376,30 +387,56 @@ type OrderArrayResponse struct {
 }
 
 type StopOrder struct {
-	OrderId           string       `json:"order_id"`
-	OrderType         string       `json:"order_type"`
-	OrderStatus       string       `json:"order_status"`
-	StopOrderId       string       `json:"stop_order_id"`
-	StopOrderType     string       `json:"stop_order_type"`
-	StopOrderStatus   string       `json:"stop_order_status"`
-	StopPx            sjson.Number `json:"stop_px"`
-	UserId            int64        `json:"user_id"`
-	Symbol            string       `json:"symbol"`
-	Side              string       `json:"side"`
-	Price             sjson.Number `json:"price"`
-	Qty               sjson.Number `json:"qty"`
-	TimeInForce       string       `json:"time_in_force"`
-	CreateType        string       `json:"create_type"`
-	CancelType        string       `json:"cancel_type"`
-	LeavesQty         sjson.Number `json:"leaves_qty"`
-	LeavesValue       string       `json:"leaves_value"`
-	CreatedAt         time.Time    `json:"created_at"`
-	UpdatedAt         time.Time    `json:"updated_at"`
-	CrossStatus       string       `json:"cross_status"`
-	CrossSeq          sjson.Number `json:"cross_seq"`
-	TriggerBy         string       `json:"trigger_by"`
-	BasePrice         sjson.Number `json:"base_price"`
-	ExpectedDirection string       `json:"expected_direction"`
+	OrderId           string          `json:"order_id"`
+	OrderType         string          `json:"order_type"`
+	OrderStatus       string          `json:"order_status"`
+	StopOrderId       string          `json:"stop_order_id"`
+	StopOrderType     string          `json:"stop_order_type"`
+	StopOrderStatus   string          `json:"stop_order_status"`
+	StopPx            decimal.Decimal `json:"stop_px"`       // inverse
+	TriggerPrice      decimal.Decimal `json:"trigger_price"` // linear
+	OrderLinkID       string          `json:"order_link_id"`
+	UserId            int64           `json:"user_id"`
+	Symbol            string          `json:"symbol"`
+	Side              string          `json:"side"`
+	Price             decimal.Decimal `json:"price"`
+	Qty               decimal.Decimal `json:"qty"`
+	TimeInForce       string          `json:"time_in_force"`
+	CreateType        string          `json:"create_type"`
+	CancelType        string          `json:"cancel_type"`
+	LeavesQty         decimal.Decimal `json:"leaves_qty"`
+	LeavesValue       decimal.Decimal `json:"leaves_value"`
+	CreatedAt         time.Time       `json:"created_at"`   // inverse
+	UpdatedAt         time.Time       `json:"updated_at"`   // inverse
+	CreatedTime       time.Time       `json:"created_time"` // linear
+	UpdatedTime       time.Time       `json:"updated_time"` // linear
+	CrossStatus       string          `json:"cross_status"`
+	CrossSeq          sjson.Number    `json:"cross_seq"`
+	TriggerBy         string          `json:"trigger_by"`
+	BasePrice         decimal.Decimal `json:"base_price"`
+	ExpectedDirection string          `json:"expected_direction"`
+	TakeProfit        decimal.Decimal `json:"take_profit"`
+	StopLoss          decimal.Decimal `json:"stop_loss"`
+	TpTriggerBy       string          `json:"tp_trigger_by"`
+	SlTriggerBy       string          `json:"sl_trigger_by"`
+	ReduceOnly        bool            `json:"reduce_only"`
+	CloseOnTrigger    bool            `json:"close_on_trigger"`
+}
+
+// Trigger returns StopPx or, for linear stop orders, TriggerPrice
+func (o *StopOrder) Trigger() decimal.Decimal {
+	if o.StopPx.IsZero() {
+		return o.TriggerPrice
+	}
+	return o.StopPx
+}
+
+// Created returns CreatedAt or, for linear stop orders, CreatedTime
+func (o *StopOrder) Created() time.Time {
+	if o.CreatedAt.IsZero() {
+		return o.CreatedTime
+	}
+	return o.CreatedAt
 }
 
 type StopOrderListResponse struct {
@@ -428,23 +465,23 @@ type StopOrderListResponsePaginated struct {
 }
 
 type StopOrderListResponseResultPaginated struct {
-	CurrentPage string      `json:"current_page"`
-	LastPage    string      `json:"last_page"`
-	Data        []StopOrder `json:"data"`
+	CurrentPage sjson.Number `json:"current_page"`
+	LastPage    sjson.Number `json:"last_page"`
+	Data        []StopOrder  `json:"data"`
 }
 
 type WalletFundRecord struct {
-	Id            int          `json:"id"`
-	UserId        int          `json:"user_id"`
-	Coin          string       `json:"coin"`
-	WalletId      int          `json:"wallet_id"`
-	Type          string       `json:"type"`
-	Amount        sjson.Number `json:"amount"`
-	TxId          string       `json:"tx_id"`
-	Address       string       `json:"address"`
-	WalletBalance sjson.Number `json:"wallet_balance"`
-	ExecTime      sjson.Number `json:"exec_time"`
-	CrossSeq      sjson.Number `json:"cross_seq"`
+	Id            int             `json:"id"`
+	UserId        int             `json:"user_id"`
+	Coin          string          `json:"coin"`
+	WalletId      int             `json:"wallet_id"`
+	Type          string          `json:"type"`
+	Amount        decimal.Decimal `json:"amount"`
+	TxId          string          `json:"tx_id"`
+	Address       string          `json:"address"`
+	WalletBalance decimal.Decimal `json:"wallet_balance"`
+	ExecTime      time.Time       `json:"exec_time"`
+	CrossSeq      sjson.Number    `json:"cross_seq"`
 }
 
 type WalletFundRecordResponse struct {
@@ -472,10 +509,10 @@ type Funding struct {
 }
 
 type FundingData struct {
-	Id     int          `json:"id"`
-	Symbol string       `json:"symbol"`
-	Value  sjson.Number `json:"value"`
-	Time   string       `json:"time"`
+	Id     int             `json:"id"`
+	Symbol string          `json:"symbol"`
+	Value  decimal.Decimal `json:"value"`
+	Time   string          `json:"time"`
 }
 
 type FundingResult struct {
@@ -484,17 +521,186 @@ type FundingResult struct {
 }
 
 type IndexOHLC struct {
-	Id      int          `json:"id"`
-	Symbol  string       `json:"symbol"`
-	Open    sjson.Number `json:"open"`
-	High    sjson.Number `json:"high"`
-	Low     sjson.Number `json:"low"`
-	Close   sjson.Number `json:"close"`
-	StartAt int          `json:"start_at"`
-	Period  string       `json:"period"`
+	Id      int             `json:"id"`
+	Symbol  string          `json:"symbol"`
+	Open    decimal.Decimal `json:"open"`
+	High    decimal.Decimal `json:"high"`
+	Low     decimal.Decimal `json:"low"`
+	Close   decimal.Decimal `json:"close"`
+	StartAt int             `json:"start_at"`
+	Period  string          `json:"period"`
 }
 
 type IndexOHLCResult struct {
 	BaseResult
 	Result []IndexOHLC `json:"result"`
 }
+
+type RiskLimit struct {
+	ID             int             `json:"id"`
+	Symbol         string          `json:"symbol"`
+	Coin           string          `json:"coin"` // inverse only
+	Limit          decimal.Decimal `json:"limit"`
+	MaintainMargin decimal.Decimal `json:"maintain_margin"`
+	StartingMargin decimal.Decimal `json:"starting_margin"`
+	Section        []string        `json:"section"`
+	IsLowestRisk   int             `json:"is_lowest_risk"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	MaxLeverage    decimal.Decimal `json:"max_leverage"`
+}
+
+type RiskLimitArrayResponse struct {
+	BaseResult
+	Result []RiskLimit `json:"result"`
+}
+
+type SetRiskLimitResult struct {
+	RiskID      int `json:"risk_id"`
+	PositionIdx int `json:"position_idx"`
+}
+
+type SetRiskLimitResponse struct {
+	BaseResult
+	Result SetRiskLimitResult `json:"result"`
+}
+
+type ChangePositionMarginResponse struct {
+	BaseResult
+	Result decimal.Decimal `json:"result"`
+}
+
+type LinearAddMarginResult struct {
+	PositionListResult LinearPosition  `json:"PositionListResult"`
+	WalletBalance      decimal.Decimal `json:"wallet_balance"`
+	AvailableBalance   decimal.Decimal `json:"available_balance"`
+}
+
+type LinearAddMarginResponse struct {
+	BaseResult
+	Result LinearAddMarginResult `json:"result"`
+}
+
+// Execution is a fill, the fields shared with ws.Execution keep its names
+type Execution struct {
+	Symbol           string          `json:"symbol"`
+	Side             string          `json:"side"`
+	OrderID          string          `json:"order_id"`
+	ExecID           string          `json:"exec_id"`
+	OrderLinkID      string          `json:"order_link_id"`
+	Price            decimal.Decimal `json:"exec_price"`
+	OrderQty         decimal.Decimal `json:"order_qty"`
+	ExecType         string          `json:"exec_type"`
+	ExecQty          decimal.Decimal `json:"exec_qty"`
+	ExecFee          decimal.Decimal `json:"exec_fee"`
+	LeavesQty        decimal.Decimal `json:"leaves_qty"`
+	IsMaker          bool            `json:"-"` // from LastLiquidityInd
+	TradeTime        time.Time       `json:"-"` // from TradeTimeMs
+	ExecValue        decimal.Decimal `json:"exec_value"`
+	FeeRate          decimal.Decimal `json:"fee_rate"`
+	OrderPrice       decimal.Decimal `json:"order_price"`
+	OrderType        string          `json:"order_type"`
+	ClosedSize       decimal.Decimal `json:"closed_size"`
+	LastLiquidityInd string          `json:"last_liquidity_ind"` // AddedLiquidity (maker) / RemovedLiquidity (taker)
+	TradeTimeMs      int64           `json:"trade_time_ms"`
+}
+
+func (e *Execution) normalize() {
+	e.IsMaker = e.LastLiquidityInd == "AddedLiquidity"
+	e.TradeTime = time.Unix(0, e.TradeTimeMs*int64(time.Millisecond))
+}
+
+type ExecutionListResponse struct {
+	BaseResult
+	Result ExecutionListResponseResult `json:"result"`
+}
+
+type ExecutionListResponseResult struct {
+	OrderID   string      `json:"order_id"`
+	TradeList []Execution `json:"trade_list"`
+}
+
+type LinearExecutionListResponse struct {
+	BaseResult
+	Result LinearExecutionListResponseResult `json:"result"`
+}
+
+type LinearExecutionListResponseResult struct {
+	CurrentPage int         `json:"current_page"`
+	Data        []Execution `json:"data"`
+}
+
+// ClosedPnl is the realised PnL of a closed (part of a) position
+type ClosedPnl struct {
+	Id            int64           `json:"id"`
+	UserId        int64           `json:"user_id"`
+	Symbol        string          `json:"symbol"`
+	OrderId       string          `json:"order_id"`
+	Side          string          `json:"side"`
+	Qty           decimal.Decimal `json:"qty"`
+	OrderPrice    decimal.Decimal `json:"order_price"`
+	OrderType     string          `json:"order_type"`
+	ExecType      string          `json:"exec_type"`
+	ClosedSize    decimal.Decimal `json:"closed_size"`
+	CumEntryValue decimal.Decimal `json:"cum_entry_value"`
+	AvgEntryPrice decimal.Decimal `json:"avg_entry_price"`
+	CumExitValue  decimal.Decimal `json:"cum_exit_value"`
+	AvgExitPrice  decimal.Decimal `json:"avg_exit_price"`
+	ClosedPnl     decimal.Decimal `json:"closed_pnl"`
+	FillCount     int             `json:"fill_count"`
+	Leverage      decimal.Decimal `json:"leverage"`
+	CreatedAt     int64           `json:"created_at"` // seconds
+}
+
+// Created returns CreatedAt as a time
+func (p *ClosedPnl) Created() time.Time {
+	return time.Unix(p.CreatedAt, 0)
+}
+
+type ClosedPnlResponse struct {
+	BaseResult
+	Result ClosedPnlResponseResult `json:"result"`
+}
+
+type ClosedPnlResponseResult struct {
+	CurrentPage int         `json:"current_page"`
+	Data        []ClosedPnl `json:"data"`
+}
+
+// FundingRate is the funding rate settled at Time
+type FundingRate struct {
+	Symbol      string          `json:"symbol"`
+	FundingRate decimal.Decimal `json:"funding_rate"`
+	Time        time.Time       `json:"funding_rate_timestamp"`
+}
+
+type FundingRateResponse struct {
+	BaseResult
+	Result FundingRate `json:"result"`
+}
+
+// FundingFee is the funding fee of the position settled at Time, negative if received
+type FundingFee struct {
+	Symbol      string          `json:"symbol"`
+	Side        string          `json:"side"`
+	Size        decimal.Decimal `json:"size"`
+	FundingRate decimal.Decimal `json:"funding_rate"`
+	ExecFee     decimal.Decimal `json:"exec_fee"`
+	Time        time.Time       `json:"exec_time"`
+}
+
+type FundingFeeResponse struct {
+	BaseResult
+	Result FundingFee `json:"result"`
+}
+
+// PredictedFunding is the funding rate and fee of the position at the next settlement
+type PredictedFunding struct {
+	PredictedFundingRate decimal.Decimal `json:"predicted_funding_rate"`
+	PredictedFundingFee  decimal.Decimal `json:"predicted_funding_fee"`
+}
+
+type PredictedFundingResponse struct {
+	BaseResult
+	Result PredictedFunding `json:"result"`
+}
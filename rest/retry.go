@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jpillora/backoff"
+)
+
+// RetryPolicy describes how failed requests are retried. Public and signed GETs
+// are retried freely, POSTs only when they are known to be idempotent, i.e.
+// order creation carrying an order_link_id.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one,
+	// values below 2 disable retrying
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, default to 200ms
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries, default to 5 seconds
+	MaxBackoff time.Duration
+	// Factor is the rate of increase of the delay, default to 2
+	Factor float64
+	// Jitter randomizes the delay between MinBackoff and the computed delay
+	Jitter bool
+	// Retryable classifies errors worth retrying, default to IsRetryable
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy retries up to 3 attempts with jittered exponential backoff
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  200 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		Factor:      2,
+		Jitter:      true,
+	}
+}
+
+// SetRetryPolicy enables retrying of failed requests, nil disables it
+func (b *ByBit) SetRetryPolicy(policy *RetryPolicy) {
+	b.retryPolicy = policy
+}
+
+// IsRetryable reports whether err is transient: network errors and timeouts, 5xx
+// responses, rate limit rejections and expired timestamps. Retrying stops anyway
+// once the request context is done.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, ErrRateLimited) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatus >= http.StatusInternalServerError || IsRateLimited(err) || IsTimestampExpired(err)
+	}
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &netErr) || errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+func (p *RetryPolicy) backoff() *backoff.Backoff {
+	bo := &backoff.Backoff{
+		Min:    p.MinBackoff,
+		Max:    p.MaxBackoff,
+		Factor: p.Factor,
+		Jitter: p.Jitter,
+	}
+	if bo.Min <= 0 {
+		bo.Min = 200 * time.Millisecond
+	}
+	if bo.Max <= 0 {
+		bo.Max = 5 * time.Second
+	}
+	if bo.Factor <= 0 {
+		bo.Factor = 2
+	}
+	return bo
+}
+
+// retry runs attempt until it succeeds or the retry policy gives up. attempt is
+// called once when idempotent is false or no policy is set, n counts the attempts from 0.
+func (b *ByBit) retry(idempotent bool, attempt func(n int) error) (err error) {
+	p := b.retryPolicy
+	if p == nil || !idempotent || p.MaxAttempts < 2 {
+		return attempt(0)
+	}
+
+	ctx := b.Context()
+	bo := p.backoff()
+	for n := 0; ; n++ {
+		err = attempt(n)
+		if err == nil || n+1 >= p.MaxAttempts || ctx.Err() != nil || !p.retryable(err) {
+			return
+		}
+		delay := bo.Duration()
+		if b.debugMode {
+			log.Printf("Retry: attempt %v failed, retrying in %v: %v", n+1, delay, err)
+		}
+		if sleepContext(ctx, delay) != nil {
+			return
+		}
+	}
+}
+
+// placeOrder sends an order creation. It is only retried when orderLinkID makes it
+// idempotent, and before every resend the order is looked up by orderLinkID so
+// that an order accepted by the exchange whose response was lost is never placed twice.
+func (b *ByBit) placeOrder(apiURL string, params map[string]interface{}, symbol string, orderLinkID string, linear bool) (query string, resp []byte, result Order, err error) {
+	var cResult OrderResponse
+	err = b.retry(orderLinkID != "", func(n int) error {
+		if n > 0 {
+			order, found, e := b.findOrderByLinkID(symbol, orderLinkID, linear)
+			if e != nil {
+				return e
+			}
+			if found {
+				if b.debugMode {
+					log.Printf("Retry: order %v already placed as %v", orderLinkID, order.OrderId)
+				}
+				cResult.Result = order
+				return nil
+			}
+		}
+		var e error
+		query, resp, e = b.SignedJSONRequest(http.MethodPost, apiURL, params, &cResult)
+		return e
+	})
+	if err != nil {
+		return
+	}
+	result = cResult.Result
+	return
+}
+
+// findOrderByLinkID looks up an active order by its user-set id, found is false when it does not exist
+func (b *ByBit) findOrderByLinkID(symbol string, orderLinkID string, linear bool) (order Order, found bool, err error) {
+	var r OrderResponse
+	if linear {
+		_, _, r, err = b.LinearGetActiveOrder(symbol, "", orderLinkID)
+	} else {
+		_, _, r, err = b.GetActiveOrder(symbol, "", orderLinkID)
+	}
+	if IsOrderNotFound(err) {
+		return order, false, nil
+	}
+	if err != nil {
+		return
+	}
+	return r.Result, r.Result.OrderId != "", nil
+}
@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func TestRetry_PublicGet(t *testing.T) {
+	var calls int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":[{"symbol":"BTCUSD"}]}`))
+	})
+	b.SetRetryPolicy(newTestRetryPolicy())
+
+	_, _, tickers, err := b.GetTickers()
+	assert.Nil(t, err)
+	assert.Len(t, tickers, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_GiveUp(t *testing.T) {
+	var calls int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"ret_code":10001,"ret_msg":"params error","result":null}`))
+	})
+	b.SetRetryPolicy(newTestRetryPolicy())
+
+	// business errors are not retried
+	_, _, _, err := b.GetTickers()
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_PostWithoutLinkID(t *testing.T) {
+	var calls int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	b.SetRetryPolicy(newTestRetryPolicy())
+
+	_, _, _, err := b.LinearCreateOrder("Buy", "Limit", 35000, 1, "GoodTillCancel", 0,
+		0, false, false, "", "BTCUSDT")
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_CreateOrderReconciled(t *testing.T) {
+	var creates, searches int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/private/linear/order/create":
+			// the order reaches the matching engine but the response is lost
+			atomic.AddInt32(&creates, 1)
+			w.WriteHeader(http.StatusGatewayTimeout)
+		case "/private/linear/order/search":
+			atomic.AddInt32(&searches, 1)
+			assert.Equal(t, "my-order-1", r.URL.Query().Get("order_link_id"))
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"abc","order_link_id":"my-order-1","symbol":"BTCUSDT"}}`))
+		}
+	})
+	b.SetRetryPolicy(newTestRetryPolicy())
+
+	_, _, order, err := b.LinearCreateOrder("Buy", "Limit", 35000, 1, "GoodTillCancel", 0,
+		0, false, false, "my-order-1", "BTCUSDT")
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", order.OrderId)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&creates))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&searches))
+}
+
+func TestRetry_CreateOrderResent(t *testing.T) {
+	var creates int32
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/private/order/create":
+			if atomic.AddInt32(&creates, 1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"order_id":"def","order_link_id":"my-order-2"}}`))
+		case "/v2/private/order":
+			w.Write([]byte(`{"ret_code":20001,"ret_msg":"order not exists","result":null}`))
+		}
+	})
+	b.SetRetryPolicy(newTestRetryPolicy())
+
+	_, _, order, err := b.CreateOrder("Buy", "Limit", 35000, 1, "GoodTillCancel", 0,
+		0, false, false, "my-order-2", "BTCUSD")
+	assert.Nil(t, err)
+	assert.Equal(t, "def", order.OrderId)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&creates))
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&APIError{HTTPStatus: http.StatusBadGateway}))
+	assert.True(t, IsRetryable(&APIError{RetCode: CodeTooManyVisits, HTTPStatus: http.StatusOK}))
+	assert.False(t, IsRetryable(&APIError{RetCode: CodeInsufficientBalance, HTTPStatus: http.StatusOK}))
+	assert.False(t, IsRetryable(ErrRateLimited))
+	assert.False(t, IsRetryable(errors.New("json: cannot unmarshal")))
+}
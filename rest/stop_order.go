@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"fmt"
+)
+
+// StopOrderRequest is an inverse perpetual conditional order, see PlaceStopOrder
+type StopOrderRequest struct {
+	Symbol         string      `json:"symbol"`
+	Side           Side        `json:"side"`
+	OrderType      OrderType   `json:"order_type"`
+	Qty            int         `json:"qty"`              // contracts (USD)
+	Price          float64     `json:"price"`            // required for stop-limit orders
+	BasePrice      float64     `json:"base_price"`       // current price, tells the direction of the trigger
+	StopPx         float64     `json:"stop_px"`          // trigger price
+	TriggerBy      TriggerBy   `json:"trigger_by"`       // LastPrice by default
+	TimeInForce    TimeInForce `json:"time_in_force"`    // GoodTillCancel by default
+	TakeProfit     float64     `json:"take_profit"`      // optional
+	StopLoss       float64     `json:"stop_loss"`        // optional
+	TpTriggerBy    TriggerBy   `json:"tp_trigger_by"`    // LastPrice by default
+	SlTriggerBy    TriggerBy   `json:"sl_trigger_by"`    // LastPrice by default
+	CloseOnTrigger bool        `json:"close_on_trigger"` // close the position, cancelling other orders if margin is short
+	OrderLinkID    string      `json:"order_link_id"`    // unique user-set id, to replace or cancel the order by
+	PositionIdx    PositionIdx `json:"position_idx"`     // required in hedge mode, see SwitchPositionMode
+}
+
+func (r *StopOrderRequest) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	params["side"] = r.Side
+	params["symbol"] = r.Symbol
+	params["order_type"] = r.OrderType
+	params["qty"] = r.Qty
+	if r.Price > 0 {
+		params["price"] = r.Price
+	}
+	params["base_price"] = r.BasePrice
+	params["stop_px"] = r.StopPx
+	params["time_in_force"] = timeInForceOrDefault(r.TimeInForce)
+	if r.TriggerBy != "" {
+		params["trigger_by"] = r.TriggerBy
+	}
+	if r.TakeProfit > 0 {
+		params["take_profit"] = r.TakeProfit
+	}
+	if r.StopLoss > 0 {
+		params["stop_loss"] = r.StopLoss
+	}
+	if r.TpTriggerBy != "" {
+		params["tp_trigger_by"] = r.TpTriggerBy
+	}
+	if r.SlTriggerBy != "" {
+		params["sl_trigger_by"] = r.SlTriggerBy
+	}
+	if r.CloseOnTrigger {
+		params["close_on_trigger"] = true
+	}
+	if r.OrderLinkID != "" {
+		params["order_link_id"] = r.OrderLinkID
+	}
+	if r.PositionIdx != PositionIdxOneWay {
+		params["position_idx"] = r.PositionIdx
+	}
+	return params
+}
+
+// LinearStopOrderRequest is a USDT perpetual conditional order, see LinearPlaceStopOrder
+type LinearStopOrderRequest struct {
+	Symbol         string      `json:"symbol"`
+	Side           Side        `json:"side"`
+	OrderType      OrderType   `json:"order_type"`
+	Qty            float64     `json:"qty"`              // base currency, e.g. BTC
+	Price          float64     `json:"price"`            // required for stop-limit orders
+	BasePrice      float64     `json:"base_price"`       // current price, tells the direction of the trigger
+	StopPx         float64     `json:"stop_px"`          // trigger price
+	TriggerBy      TriggerBy   `json:"trigger_by"`       // LastPrice by default
+	TimeInForce    TimeInForce `json:"time_in_force"`    // GoodTillCancel by default
+	TakeProfit     float64     `json:"take_profit"`      // optional
+	StopLoss       float64     `json:"stop_loss"`        // optional
+	TpTriggerBy    TriggerBy   `json:"tp_trigger_by"`    // LastPrice by default
+	SlTriggerBy    TriggerBy   `json:"sl_trigger_by"`    // LastPrice by default
+	ReduceOnly     bool        `json:"reduce_only"`      // only reduce the position
+	CloseOnTrigger bool        `json:"close_on_trigger"` // close the position, cancelling other orders if margin is short
+	OrderLinkID    string      `json:"order_link_id"`    // unique user-set id, to replace or cancel the order by
+	PositionIdx    PositionIdx `json:"position_idx"`     // required in hedge mode
+}
+
+func (r *LinearStopOrderRequest) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	params["side"] = r.Side
+	params["symbol"] = r.Symbol
+	params["order_type"] = r.OrderType
+	params["qty"] = r.Qty
+	if r.Price > 0 {
+		params["price"] = r.Price
+	}
+	params["base_price"] = r.BasePrice
+	params["stop_px"] = r.StopPx
+	params["time_in_force"] = timeInForceOrDefault(r.TimeInForce)
+	if r.TriggerBy != "" {
+		params["trigger_by"] = r.TriggerBy
+	}
+	if r.TakeProfit > 0 {
+		params["take_profit"] = r.TakeProfit
+	}
+	if r.StopLoss > 0 {
+		params["stop_loss"] = r.StopLoss
+	}
+	if r.TpTriggerBy != "" {
+		params["tp_trigger_by"] = r.TpTriggerBy
+	}
+	if r.SlTriggerBy != "" {
+		params["sl_trigger_by"] = r.SlTriggerBy
+	}
+	params["reduce_only"] = r.ReduceOnly
+	params["close_on_trigger"] = r.CloseOnTrigger
+	if r.OrderLinkID != "" {
+		params["order_link_id"] = r.OrderLinkID
+	}
+	if r.PositionIdx != PositionIdxOneWay {
+		params["position_idx"] = r.PositionIdx
+	}
+	return params
+}
+
+// ReplaceStopOrderRequest amends an untriggered conditional order identified by
+// StopOrderID or OrderLinkID. Zero qty and prices are left unchanged, nil take
+// profit and stop loss too while 0 cancels them. The trigger source of the
+// order itself cannot be amended, replace the order to change it.
+type ReplaceStopOrderRequest struct {
+	Symbol       string    `json:"symbol"`
+	StopOrderID  string    `json:"stop_order_id"`
+	OrderLinkID  string    `json:"order_link_id"`
+	Qty          float64   `json:"p_r_qty"` // whole contracts for inverse orders
+	Price        float64   `json:"p_r_price"`
+	TriggerPrice float64   `json:"p_r_trigger_price"`
+	TakeProfit   *float64  `json:"take_profit"`
+	StopLoss     *float64  `json:"stop_loss"`
+	TpTriggerBy  TriggerBy `json:"tp_trigger_by"`
+	SlTriggerBy  TriggerBy `json:"sl_trigger_by"`
+}
+
+func (r *ReplaceStopOrderRequest) params(linear bool) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+	params["symbol"] = r.Symbol
+	if err := stopOrderRef(params, r.StopOrderID, r.OrderLinkID); err != nil {
+		return nil, err
+	}
+	if r.Qty > 0 {
+		if linear {
+			params["p_r_qty"] = r.Qty
+		} else {
+			qty, err := wholeNumber(r.Qty, "qty")
+			if err != nil {
+				return nil, err
+			}
+			params["p_r_qty"] = qty
+		}
+	}
+	if r.Price > 0 {
+		params["p_r_price"] = r.Price
+	}
+	if r.TriggerPrice > 0 {
+		params["p_r_trigger_price"] = r.TriggerPrice
+	}
+	if r.TakeProfit != nil {
+		params["take_profit"] = *r.TakeProfit
+	}
+	if r.StopLoss != nil {
+		params["stop_loss"] = *r.StopLoss
+	}
+	if r.TpTriggerBy != "" {
+		params["tp_trigger_by"] = r.TpTriggerBy
+	}
+	if r.SlTriggerBy != "" {
+		params["sl_trigger_by"] = r.SlTriggerBy
+	}
+	return params, nil
+}
+
+// stopOrderRef sets the id a conditional order is replaced or cancelled by,
+// the exchange id when both are given
+func stopOrderRef(params map[string]interface{}, stopOrderID string, orderLinkID string) error {
+	switch {
+	case stopOrderID != "":
+		params["stop_order_id"] = stopOrderID
+	case orderLinkID != "":
+		params["order_link_id"] = orderLinkID
+	default:
+		return fmt.Errorf("%w: stop order without stop_order_id nor order_link_id", ErrInvalidOrder)
+	}
+	return nil
+}
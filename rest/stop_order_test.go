@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByBit_LinearPlaceStopOrder(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"stop_order_id":"s1","symbol":"BTCUSDT","trigger_price":36000,"order_link_id":"sl-1","take_profit":38000,"tp_trigger_by":"MarkPrice","trigger_by":"IndexPrice","reduce_only":true}}`))
+	})
+
+	_, _, order, err := b.LinearPlaceStopOrder(LinearStopOrderRequest{
+		Symbol:      "BTCUSDT",
+		Side:        SideBuy,
+		OrderType:   OrderTypeMarket,
+		Qty:         0.01,
+		BasePrice:   35000,
+		StopPx:      36000,
+		TriggerBy:   TriggerByIndexPrice,
+		TakeProfit:  38000,
+		TpTriggerBy: TriggerByMarkPrice,
+		ReduceOnly:  true,
+		OrderLinkID: "sl-1",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "s1", order.StopOrderId)
+	assert.Equal(t, "sl-1", order.OrderLinkID)
+	assert.Equal(t, "36000", order.Trigger().String())
+	assert.Equal(t, "38000", order.TakeProfit.String())
+	assert.True(t, order.ReduceOnly)
+	assert.Equal(t, "IndexPrice", body["trigger_by"])
+	assert.Equal(t, float64(36000), body["stop_px"])
+	assert.Equal(t, float64(38000), body["take_profit"])
+	assert.Equal(t, "MarkPrice", body["tp_trigger_by"])
+	assert.Equal(t, "sl-1", body["order_link_id"])
+	assert.Equal(t, "GoodTillCancel", body["time_in_force"])
+	assert.NotContains(t, body, "price")
+	assert.NotContains(t, body, "stop_loss")
+}
+
+func TestByBit_AmendStopOrderByLinkID(t *testing.T) {
+	var paths []string
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"stop_order_id":"s2"}}`))
+	})
+
+	_, _, order, err := b.AmendStopOrder(ReplaceStopOrderRequest{
+		Symbol:       "BTCUSD",
+		OrderLinkID:  "sl-2",
+		Qty:          20,
+		TriggerPrice: 34000,
+		StopLoss:     Float64(0),
+		SlTriggerBy:  TriggerByLastPrice,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "s2", order.StopOrderId)
+	assert.Equal(t, "sl-2", body["order_link_id"])
+	assert.NotContains(t, body, "stop_order_id")
+	assert.Equal(t, float64(20), body["p_r_qty"])
+	assert.Equal(t, float64(34000), body["p_r_trigger_price"])
+	assert.Equal(t, float64(0), body["stop_loss"], "0 cancels the stop loss")
+	assert.NotContains(t, body, "take_profit")
+	assert.NotContains(t, body, "p_r_price")
+
+	_, _, _, err = b.LinearCancelStopOrderByLinkID("sl-3", "BTCUSDT")
+	assert.Nil(t, err)
+	assert.Equal(t, "sl-3", body["order_link_id"])
+	assert.Equal(t, []string{"/v2/private/stop-order/replace", "/private/linear/stop-order/cancel"}, paths)
+
+	_, _, _, err = b.AmendStopOrder(ReplaceStopOrderRequest{Symbol: "BTCUSD", OrderLinkID: "sl-2", Qty: 1.5})
+	assert.True(t, errors.Is(err, ErrInvalidOrder), "inverse qty must be whole")
+	_, _, _, err = b.CancelStopOrder("", "BTCUSD")
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	assert.Len(t, paths, 2)
+}
+
+func TestByBit_PlaceStopOrderHedgeMode(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestByBit(t, func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"ret_code":0,"ret_msg":"OK","result":{"stop_order_id":"s2"}}`))
+	})
+
+	_, _, _, err := b.PlaceStopOrder(StopOrderRequest{
+		Symbol:      "BTCUSD",
+		Side:        SideSell,
+		OrderType:   OrderTypeMarket,
+		Qty:         100,
+		BasePrice:   35000,
+		StopPx:      34000,
+		PositionIdx: PositionIdxHedgeSell,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(2), body["position_idx"])
+}
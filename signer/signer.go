@@ -0,0 +1,90 @@
+// Package signer provides the request signatures used by the Bybit REST and
+// WebSocket APIs, so that the secret can live outside of the api clients.
+package signer
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+)
+
+// Signer signs the payload of a request and returns the value of its sign parameter
+type Signer interface {
+	Sign(payload []byte) (string, error)
+}
+
+// Func adapts a function, e.g. a call to an external signing daemon, to a Signer
+type Func func(payload []byte) (string, error)
+
+// Sign calls f(payload)
+func (f Func) Sign(payload []byte) (string, error) {
+	return f(payload)
+}
+
+// HMAC signs with HMAC-SHA256 over the api secret, hex encoded
+type HMAC struct {
+	secret []byte
+}
+
+// NewHMAC creates a signer for a system-generated HMAC api key
+func NewHMAC(secret string) *HMAC {
+	return &HMAC{
+		secret: []byte(secret),
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of payload
+func (s *HMAC) Sign(payload []byte) (string, error) {
+	sig := hmac.New(sha256.New, s.secret)
+	sig.Write(payload)
+	return hex.EncodeToString(sig.Sum(nil)), nil
+}
+
+// RSA signs with RSA-SHA256 (PKCS #1 v1.5), base64 encoded
+type RSA struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSA creates a signer for a self-generated RSA api key
+func NewRSA(key *rsa.PrivateKey) *RSA {
+	return &RSA{
+		key: key,
+	}
+}
+
+// ParseRSA creates an RSA signer from a PEM encoded PKCS #1 or PKCS #8 private key
+func ParseRSA(pemBytes []byte) (*RSA, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("signer: no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewRSA(key), nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signer: not an RSA private key")
+	}
+	return NewRSA(key), nil
+}
+
+// Sign returns the base64 encoded RSA-SHA256 signature of payload
+func (s *RSA) Sign(payload []byte) (string, error) {
+	hashed := sha256.Sum256(payload)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
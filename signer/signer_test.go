@@ -0,0 +1,71 @@
+package signer
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMAC_Sign(t *testing.T) {
+	// https://bybit-exchange.github.io/docs/inverse/#t-constructingtherequest
+	s := NewHMAC("t7T0YlFnYXk0Fx3JswQsDrViLg1Gh3DUU5Mr")
+	sig, err := s.Sign([]byte("api_key=B2Rou0PLPpGqcU0Vu2&leverage=100&symbol=BTCUSD&timestamp=1542434791000"))
+	assert.Nil(t, err)
+	assert.Equal(t, "670e3e4aa32b243f2dedf1dafcec2fd17a440e71b05681550416507de591d908", sig)
+}
+
+func TestRSA_Sign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, block := range []*pem.Block{
+		{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		{Type: "PRIVATE KEY", Bytes: mustPKCS8(t, key)},
+	} {
+		s, err := ParseRSA(pem.EncodeToMemory(block))
+		if !assert.Nil(t, err) {
+			continue
+		}
+
+		payload := []byte("GET/realtime1662350400000")
+		sig, err := s.Sign(payload)
+		assert.Nil(t, err)
+
+		raw, err := base64.StdEncoding.DecodeString(sig)
+		assert.Nil(t, err)
+		hashed := sha256.Sum256(payload)
+		assert.Nil(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], raw))
+	}
+
+	_, err = ParseRSA([]byte("not a key"))
+	assert.NotNil(t, err)
+}
+
+func TestFunc_Sign(t *testing.T) {
+	var s Signer = Func(func(payload []byte) (string, error) {
+		if len(payload) == 0 {
+			return "", errors.New("empty payload")
+		}
+		return "signed:" + string(payload), nil
+	})
+	sig, err := s.Sign([]byte("x"))
+	assert.Nil(t, err)
+	assert.Equal(t, "signed:x", sig)
+}
+
+func mustPKCS8(t *testing.T, key *rsa.PrivateKey) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
@@ -0,0 +1,211 @@
+package ws
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultAckTimeout is how long a command waits for its acknowledgement
+const defaultAckTimeout = 5 * time.Second
+
+// CmdError is a command rejected by the server, not acknowledged in time or
+// whose connection dropped before its acknowledgement, it is emitted as WSError
+type CmdError struct {
+	Request Cmd    // the failed command
+	RetMsg  string // reason given by the server
+	Timeout bool   // no acknowledgement arrived within Configuration.AckTimeout
+	Dropped bool   // the connection dropped first, subscriptions and auth are replayed on reconnect
+}
+
+func (e *CmdError) Error() string {
+	cmd := e.Request.Op
+	if topics := cmdTopics(e.Request); topics != "" {
+		cmd += " " + topics
+	}
+	if e.Timeout {
+		return fmt.Sprintf("BybitWs %v: no acknowledgement", cmd)
+	}
+	if e.Dropped {
+		return fmt.Sprintf("BybitWs %v: connection dropped before acknowledgement", cmd)
+	}
+	return fmt.Sprintf("BybitWs %v rejected: %v", cmd, e.RetMsg)
+}
+
+// cmdTopics returns the args of cmd but the credentials of auth commands
+func cmdTopics(cmd Cmd) string {
+	if cmd.Op == "auth" {
+		return ""
+	}
+	var args []string
+	for _, arg := range cmd.Args {
+		args = append(args, fmt.Sprint(arg))
+	}
+	return strings.Join(args, ",")
+}
+
+// ackKey matches a command with its acknowledgement, auth commands by op
+// only as the server may echo their args reformatted
+func ackKey(cmd Cmd) string {
+	if cmd.Op == "auth" {
+		return cmd.Op
+	}
+	return cmd.Op + ":" + cmdTopics(cmd)
+}
+
+// pendingCmd is a command waiting for its acknowledgement
+type pendingCmd struct {
+	cmd   Cmd
+	timer *time.Timer
+	done  chan error // receives nil or the *CmdError, buffered
+}
+
+// acks holds the commands waiting for their acknowledgement, oldest first
+type acks struct {
+	mu      sync.Mutex
+	pending map[string][]*pendingCmd
+}
+
+// add adds p and starts its timer running expire after timeout
+func (a *acks) add(p *pendingCmd, timeout time.Duration, expire func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.pending == nil {
+		a.pending = make(map[string][]*pendingCmd)
+	}
+	key := ackKey(p.cmd)
+	a.pending[key] = append(a.pending[key], p)
+	p.timer = time.AfterFunc(timeout, expire)
+}
+
+// remove removes p, false if it was already acknowledged or timed out
+func (a *acks) remove(p *pendingCmd) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := ackKey(p.cmd)
+	for i, q := range a.pending[key] {
+		if q == p {
+			a.pending[key] = append(a.pending[key][:i:i], a.pending[key][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// pop removes the oldest command waiting for the acknowledgement of cmd
+func (a *acks) pop(cmd Cmd) *pendingCmd {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := ackKey(cmd)
+	if len(a.pending[key]) == 0 {
+		return nil
+	}
+	p := a.pending[key][0]
+	a.pending[key] = a.pending[key][1:]
+	return p
+}
+
+// drain removes every command waiting for its acknowledgement and stops their timers
+func (a *acks) drain() []*pendingCmd {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var drained []*pendingCmd
+	for _, list := range a.pending {
+		for _, p := range list {
+			p.timer.Stop()
+			drained = append(drained, p)
+		}
+	}
+	a.pending = nil
+	return drained
+}
+
+func (b *ByBitWS) ackTimeout() time.Duration {
+	if b.cfg.AckTimeout > 0 {
+		return b.cfg.AckTimeout
+	}
+	return defaultAckTimeout
+}
+
+// sendTracked sends cmd and returns the channel receiving its outcome once
+// acknowledged or timed out. Failures are also emitted as WSError.
+func (b *ByBitWS) sendTracked(cmd Cmd) (<-chan error, error) {
+	p := &pendingCmd{
+		cmd:  cmd,
+		done: make(chan error, 1),
+	}
+	b.acks.add(p, b.ackTimeout(), func() {
+		if b.acks.remove(p) {
+			b.fail(p, &CmdError{Request: cmd, Timeout: true})
+		}
+	})
+	if err := b.SendCmd(cmd); err != nil {
+		p.timer.Stop()
+		b.acks.remove(p)
+		return nil, err
+	}
+	return p.done, nil
+}
+
+// sendAndWait sends cmd and waits for its acknowledgement
+func (b *ByBitWS) sendAndWait(cmd Cmd) error {
+	done, err := b.sendTracked(cmd)
+	if err != nil {
+		return err
+	}
+	return <-done
+}
+
+func (b *ByBitWS) fail(p *pendingCmd, err *CmdError) {
+	p.done <- err
+	if b.cfg.DebugMode {
+		log.Printf("BybitWs %v", err)
+	}
+	for _, h := range b.handlers.get(WSError) {
+		h.fn.(func(*CmdError))(err)
+	}
+	b.Emit(WSError, err)
+}
+
+// failPending fails the commands sent on a dropped connection, as their
+// acknowledgement never comes and would otherwise complete the commands
+// replayed on reconnect
+func (b *ByBitWS) failPending() {
+	for _, p := range b.acks.drain() {
+		b.fail(p, &CmdError{Request: p.cmd, Dropped: true})
+	}
+}
+
+// processAck completes the command acknowledged by ack. A rejected
+// subscription is dropped from the subscriptions replayed on reconnect.
+func (b *ByBitWS) processAck(ack *Ack) {
+	for _, h := range b.handlers.get(WSAck) {
+		h.fn.(func(*Ack))(ack)
+	}
+	b.Emit(WSAck, ack)
+
+	p := b.acks.pop(ack.Request)
+	if p == nil {
+		return
+	}
+	p.timer.Stop()
+	if ack.Success {
+		p.done <- nil
+		if p.cmd.Op == "auth" {
+			b.emitState(ConnectionEvent{Event: WSAuthenticated})
+		}
+		return
+	}
+	if p.cmd.Op == "subscribe" {
+		for _, arg := range p.cmd.Args {
+			b.removeSubscription(fmt.Sprint(arg))
+		}
+	}
+	b.fail(p, &CmdError{Request: p.cmd, RetMsg: ack.RetMsg})
+}
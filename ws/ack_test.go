@@ -0,0 +1,124 @@
+package ws
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// startTestClient connects a client to a test server answering every command with reply
+func startTestClient(t *testing.T, cfg *Configuration, reply func(cmd string) string) *ByBitWS {
+	addr, msgs, conns := newTestServer(t)
+	cfg.Addr = addr
+	b := New(cfg)
+	b.conn.HandshakeTimeout = 100 * time.Millisecond
+	b.Start()
+	server := <-conns
+	go func() {
+		for msg := range msgs {
+			if r := reply(msg); r != "" {
+				server.WriteMessage(websocket.TextMessage, []byte(r))
+			}
+		}
+	}()
+	return b
+}
+
+func TestSubscribeAndWait(t *testing.T) {
+	b := startTestClient(t, &Configuration{}, func(cmd string) string {
+		switch cmd {
+		case `{"op":"subscribe","args":["trade.BTCUSD"]}`:
+			return `{"success":true,"ret_msg":"","conn_id":"c1","request":{"op":"subscribe","args":["trade.BTCUSD"]}}`
+		case `{"op":"subscribe","args":["trade.BTCUSX"]}`:
+			return `{"success":false,"ret_msg":"error:handler not found,topic:trade.BTCUSX","conn_id":"c1","request":{"op":"subscribe","args":["trade.BTCUSX"]}}`
+		}
+		return ""
+	})
+	events := make(chan *CmdError, 1)
+	b.On(WSError, func(err *CmdError) {
+		events <- err
+	})
+
+	if err := b.SubscribeAndWait("trade.BTCUSD"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := b.SubscribeAndWait("trade.BTCUSX")
+	var cmdErr *CmdError
+	if !errors.As(err, &cmdErr) || cmdErr.Timeout || cmdErr.RetMsg != "error:handler not found,topic:trade.BTCUSX" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	select {
+	case e := <-events:
+		if e != cmdErr {
+			t.Errorf("unexpected event %v", e)
+		}
+	case <-time.After(time.Second):
+		t.Error("no error event")
+	}
+	if got := b.Subscriptions(); len(got) != 1 || got[0] != "trade.BTCUSD" {
+		t.Errorf("the rejected topic is dropped, got %v", got)
+	}
+}
+
+func TestAuthAndWaitTimeout(t *testing.T) {
+	b := startTestClient(t, &Configuration{
+		ApiKey:     "key",
+		SecretKey:  "secret",
+		AckTimeout: 50 * time.Millisecond,
+	}, func(cmd string) string { return "" })
+
+	err := b.AuthAndWait()
+	var cmdErr *CmdError
+	if !errors.As(err, &cmdErr) || !cmdErr.Timeout || cmdErr.Request.Op != "auth" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if cmdErr.Error() != "BybitWs auth: no acknowledgement" {
+		t.Errorf("unexpected message %v", cmdErr)
+	}
+}
+
+func TestPendingCmdDroppedOnDisconnect(t *testing.T) {
+	addr, msgs, conns := newTestServer(t)
+	b := newTestClient(addr, true)
+	b.cfg.ApiKey = "key"
+	b.cfg.SecretKey = "secret"
+	b.cfg.AckTimeout = 200 * time.Millisecond
+	errs := make(chan *CmdError, 10)
+	b.OnCmdError(func(err *CmdError) {
+		errs <- err
+	})
+	b.Start()
+	defer b.Close()
+
+	// the auth sent on connect is never acknowledged
+	server := <-conns
+	if msg := nextCmd(t, msgs); !strings.HasPrefix(msg, `{"op":"auth"`) {
+		t.Fatalf("unexpected %v", msg)
+	}
+	server.Close()
+
+	// the replayed auth is
+	server = <-conns
+	if msg := nextCmd(t, msgs); !strings.HasPrefix(msg, `{"op":"auth"`) {
+		t.Fatalf("unexpected %v", msg)
+	}
+	server.WriteMessage(websocket.TextMessage, []byte(`{"success":true,"ret_msg":"","request":{"op":"auth","args":["key"]}}`))
+
+	select {
+	case err := <-errs:
+		if !err.Dropped || err.Request.Op != "auth" {
+			t.Errorf("unexpected error %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the dropped auth did not fail")
+	}
+	select {
+	case err := <-errs:
+		t.Errorf("unexpected error after the replayed auth %v", err)
+	case <-time.After(2 * b.cfg.AckTimeout):
+	}
+}
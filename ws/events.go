@@ -2,7 +2,8 @@ package ws
 
 import "github.com/chuckpreslar/emission"
 
-//On adds a listener to a specific event
+//On adds a listener to a specific event, the listener is called by reflection and
+//panics on a wrong signature: prefer the typed handlers such as OnTrade
 func (b *ByBitWS) On(event interface{}, listener interface{}) *emission.Emitter {
 	return b.emitter.On(event, listener)
 }
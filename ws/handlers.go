@@ -0,0 +1,147 @@
+package ws
+
+import "sync"
+
+// connectionEvents is the handler key of every ConnectionEvent, see OnConnectionEvent
+const connectionEvents = "connection_event"
+
+// handler is a typed handler function and its registration id
+type handler struct {
+	id int
+	fn interface{}
+}
+
+// handlers holds the typed handlers of every event in registration order.
+// The slices are copied on write so that dispatching reads them without locking
+// for the whole dispatch.
+type handlers struct {
+	mu     sync.RWMutex
+	nextID int
+	events map[string][]handler
+}
+
+// add registers fn for event and returns the function unregistering it
+func (h *handlers) add(event string, fn interface{}) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.events == nil {
+		h.events = make(map[string][]handler)
+	}
+	h.nextID++
+	id := h.nextID
+	list := h.events[event]
+	h.events[event] = append(list[:len(list):len(list)], handler{id: id, fn: fn})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			h.remove(event, id)
+		})
+	}
+}
+
+func (h *handlers) remove(event string, id int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	list := h.events[event]
+	for i, x := range list {
+		if x.id == id {
+			h.events[event] = append(list[:i:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+// get returns the handlers of event, the slice must not be modified
+func (h *handlers) get(event string) []handler {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.events[event]
+}
+
+// The typed handlers below are called on the read loop, one after the other in
+// registration order, before the listeners added by On. Each returns the
+// function unregistering the handler, which may be called more than once.
+
+// OnOrderBook handles the local order books updated by WSOrderBook25L1
+func (b *ByBitWS) OnOrderBook(fn func(symbol string, ob OrderBook)) func() {
+	return b.handlers.add(WSOrderBook25L1, fn)
+}
+
+// OnTrade handles WSTrade
+func (b *ByBitWS) OnTrade(fn func(symbol string, trades []*Trade)) func() {
+	return b.handlers.add(WSTrade, fn)
+}
+
+// OnKLine handles WSKLine
+func (b *ByBitWS) OnKLine(fn func(symbol string, kline KLine)) func() {
+	return b.handlers.add(WSKLine, fn)
+}
+
+// OnKLineV2 handles WSKLineV2
+func (b *ByBitWS) OnKLineV2(fn func(symbol string, klines []*KLineV2)) func() {
+	return b.handlers.add(WSKLineV2, fn)
+}
+
+// OnCandle handles the USDT perpetual klines of WSCandle
+func (b *ByBitWS) OnCandle(fn func(symbol string, candles []*KLineV2)) func() {
+	return b.handlers.add(WSCandle, fn)
+}
+
+// OnInsurance handles WSInsurance
+func (b *ByBitWS) OnInsurance(fn func(currency string, insurances []*Insurance)) func() {
+	return b.handlers.add(WSInsurance, fn)
+}
+
+// OnInstrument handles WSInstrument
+func (b *ByBitWS) OnInstrument(fn func(symbol string, instruments []*Instrument)) func() {
+	return b.handlers.add(WSInstrument, fn)
+}
+
+// OnLiquidation handles WSLiquidation
+func (b *ByBitWS) OnLiquidation(fn func(symbol string, liquidation *Liquidation)) func() {
+	return b.handlers.add(WSLiquidation, fn)
+}
+
+// OnPosition handles WSPosition
+func (b *ByBitWS) OnPosition(fn func(positions []*Position)) func() {
+	return b.handlers.add(WSPosition, fn)
+}
+
+// OnExecution handles WSExecution
+func (b *ByBitWS) OnExecution(fn func(executions []*Execution)) func() {
+	return b.handlers.add(WSExecution, fn)
+}
+
+// OnOrder handles WSOrder
+func (b *ByBitWS) OnOrder(fn func(orders []*Order)) func() {
+	return b.handlers.add(WSOrder, fn)
+}
+
+// OnStopOrder handles WSStopOrder
+func (b *ByBitWS) OnStopOrder(fn func(orders []*StopOrder)) func() {
+	return b.handlers.add(WSStopOrder, fn)
+}
+
+// OnWallet handles WSWallet
+func (b *ByBitWS) OnWallet(fn func(wallets []*Wallet)) func() {
+	return b.handlers.add(WSWallet, fn)
+}
+
+// OnAck handles WSAck
+func (b *ByBitWS) OnAck(fn func(ack *Ack)) func() {
+	return b.handlers.add(WSAck, fn)
+}
+
+// OnCmdError handles the rejected or unacknowledged commands of WSError
+func (b *ByBitWS) OnCmdError(fn func(err *CmdError)) func() {
+	return b.handlers.add(WSError, fn)
+}
+
+// OnConnectionEvent handles every connection state change, from WSDialing to WSGaveUp
+func (b *ByBitWS) OnConnectionEvent(fn func(event ConnectionEvent)) func() {
+	return b.handlers.add(connectionEvents, fn)
+}
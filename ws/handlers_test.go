@@ -0,0 +1,66 @@
+package ws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTypedHandlers(t *testing.T) {
+	b := New(&Configuration{})
+	var got []string
+	unsubscribe := b.OnTrade(func(symbol string, trades []*Trade) {
+		got = append(got, "first "+symbol+" "+trades[0].Side)
+	})
+	b.OnTrade(func(symbol string, trades []*Trade) {
+		got = append(got, "second "+symbol)
+	})
+	legacy := make(chan []*Trade, 1)
+	b.On(WSTrade, func(symbol string, trades []*Trade) {
+		legacy <- trades
+	})
+
+	msg := []byte(`{"topic":"trade.BTCUSD","data":[{"timestamp":"2022-01-25T02:06:25.000Z","symbol":"BTCUSD","side":"Buy","size":10,"price":36000,"tick_direction":"PlusTick","trade_id":"t1","cross_seq":1}]}`)
+	if err := b.processMessage(1, msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != "first BTCUSD Buy" || got[1] != "second BTCUSD" {
+		t.Errorf("unexpected calls %v", got)
+	}
+	select {
+	case trades := <-legacy:
+		if len(trades) != 1 {
+			t.Errorf("unexpected legacy trades %v", trades)
+		}
+	case <-time.After(time.Second):
+		t.Error("the legacy listener was not called")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	got = nil
+	if err := b.processMessage(1, msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "second BTCUSD" {
+		t.Errorf("unexpected calls after unsubscribe %v", got)
+	}
+}
+
+func TestTypedConnectionHandler(t *testing.T) {
+	addr, _, conns := newTestServer(t)
+	b := newTestClient(addr, true)
+	states := make(chan ConnectionEvent, 10)
+	b.OnConnectionEvent(func(event ConnectionEvent) {
+		states <- event
+	})
+	b.Start()
+	defer b.Close()
+	<-conns
+
+	if event := nextState(t, states); event.Event != WSDialing {
+		t.Errorf("unexpected %+v", event)
+	}
+	if event := nextState(t, states); event.Event != WSConnected {
+		t.Errorf("unexpected %+v", event)
+	}
+}
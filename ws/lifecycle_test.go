@@ -0,0 +1,149 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func newTestClient(addr string, autoReconnect bool) *ByBitWS {
+	b := New(&Configuration{Addr: addr, AutoReconnect: autoReconnect})
+	b.conn.HandshakeTimeout = 100 * time.Millisecond
+	b.conn.RecIntvlMin = 10 * time.Millisecond
+	b.conn.RecIntvlMax = 50 * time.Millisecond
+	return b
+}
+
+func waitDone(t *testing.T, b *ByBitWS) {
+	select {
+	case <-b.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection not done")
+	}
+}
+
+func TestClose(t *testing.T) {
+	addr, _, conns := newTestServer(t)
+	b := newTestClient(addr, true)
+	b.Start()
+	<-conns
+
+	b.Close()
+	waitDone(t, b)
+	if b.Err() != nil || b.IsConnected() || !b.Ended {
+		t.Errorf("unexpected state err %v connected %v", b.Err(), b.IsConnected())
+	}
+	if b.Start() == nil {
+		t.Error("a closed connection cannot be restarted")
+	}
+
+	// closing a connection never started
+	b = newTestClient(addr, true)
+	b.Close()
+	waitDone(t, b)
+}
+
+func TestReadLoopSurvivesReconnect(t *testing.T) {
+	addr, msgs, conns := newTestServer(t)
+	b := newTestClient(addr, true)
+	acked := make(chan *Ack, 10)
+	b.On(WSAck, func(ack *Ack) {
+		acked <- ack
+	})
+	b.Subscribe("trade.BTCUSD")
+	b.Start()
+	defer b.Close()
+	server := <-conns
+	nextCmd(t, msgs)
+
+	server.Close()
+	server = <-conns
+	if msg := nextCmd(t, msgs); msg != `{"op":"subscribe","args":["trade.BTCUSD"]}` {
+		t.Fatalf("unexpected %v", msg)
+	}
+	server.WriteMessage(1, []byte(`{"success":true,"ret_msg":"","request":{"op":"subscribe","args":["trade.BTCUSD"]}}`))
+	select {
+	case ack := <-acked:
+		if !ack.Success {
+			t.Errorf("unexpected ack %v", ack)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the read loop did not survive the reconnection")
+	}
+}
+
+func TestReadErrorWithoutAutoReconnect(t *testing.T) {
+	addr, _, conns := newTestServer(t)
+	b := newTestClient(addr, false)
+	b.Start()
+	server := <-conns
+
+	server.Close()
+	waitDone(t, b)
+	if b.Err() == nil {
+		t.Error("no terminal error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	addr, _, conns := newTestServer(t)
+	b := newTestClient(addr, true)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-conns
+		cancel()
+	}()
+
+	if err := b.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestCloseAbortsDial(t *testing.T) {
+	// a server never completing the handshake
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		var held []net.Conn
+		defer func() {
+			for _, c := range held {
+				c.Close()
+			}
+		}()
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			held = append(held, c)
+		}
+	}()
+
+	b := newTestClient("ws://"+l.Addr().String(), true)
+	b.conn.HandshakeTimeout = 300 * time.Millisecond
+	b.conn.MaxAttempts = 2
+	states := recordStates(b)
+	b.Start()
+	for attempt := 1; attempt <= 2; attempt++ {
+		if event := nextState(t, states); event.Event != WSDialing || event.Attempt != attempt {
+			t.Fatalf("unexpected %+v", event)
+		}
+	}
+
+	// the second attempt is dialing
+	start := time.Now()
+	b.Close()
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("Close waited %v for the dial", elapsed)
+	}
+	select {
+	case event := <-states:
+		t.Errorf("unexpected %+v after Close", event)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
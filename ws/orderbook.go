@@ -1,11 +1,15 @@
 package ws
 
-import "time"
+import (
+	"time"
+
+	"github.com/wilcosheh/bybit-api/decimal"
+)
 
 // Item stores the amount and price values
 type Item struct {
-	Amount float64 `json:"amount"`
-	Price  float64 `json:"price"`
+	Amount decimal.Decimal `json:"amount"`
+	Price  decimal.Decimal `json:"price"`
 }
 
 type OrderBook struct {
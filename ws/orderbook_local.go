@@ -17,22 +17,22 @@ func (o *OrderBookLocal) GetOrderBook() (ob OrderBook) {
 		case "Buy":
 			ob.Bids = append(ob.Bids, Item{
 				Price:  v.Price,
-				Amount: float64(v.Size),
+				Amount: v.Size,
 			})
 		case "Sell":
 			ob.Asks = append(ob.Asks, Item{
 				Price:  v.Price,
-				Amount: float64(v.Size),
+				Amount: v.Size,
 			})
 		}
 	}
 
 	sort.Slice(ob.Bids, func(i, j int) bool {
-		return ob.Bids[i].Price > ob.Bids[j].Price
+		return ob.Bids[i].Price.GreaterThan(ob.Bids[j].Price)
 	})
 
 	sort.Slice(ob.Asks, func(i, j int) bool {
-		return ob.Asks[i].Price < ob.Asks[j].Price
+		return ob.Asks[i].Price.LessThan(ob.Asks[j].Price)
 	})
 
 	ob.Timestamp = time.Now()
@@ -11,7 +11,7 @@ func (b *ByBitWS) processOrderBookSnapshot(symbol string, ob ...*OrderBookL2) {
 	}
 	value.LoadSnapshot(ob)
 
-	b.Emit(WSOrderBook25L1, symbol, value.GetOrderBook())
+	b.emitOrderBook(symbol, value.GetOrderBook())
 }
 
 func (b *ByBitWS) processOrderBookDelta(symbol string, delta *OrderBookL2Delta) {
@@ -21,53 +21,96 @@ func (b *ByBitWS) processOrderBookDelta(symbol string, delta *OrderBookL2Delta)
 	}
 	value.Update(delta)
 
-	b.Emit(WSOrderBook25L1, symbol, value.GetOrderBook())
+	b.emitOrderBook(symbol, value.GetOrderBook())
+}
+
+func (b *ByBitWS) emitOrderBook(symbol string, ob OrderBook) {
+	for _, h := range b.handlers.get(WSOrderBook25L1) {
+		h.fn.(func(string, OrderBook))(symbol, ob)
+	}
+	b.Emit(WSOrderBook25L1, symbol, ob)
 }
 
 func (b *ByBitWS) processTrade(symbol string, data ...*Trade) {
+	for _, h := range b.handlers.get(WSTrade) {
+		h.fn.(func(string, []*Trade))(symbol, data)
+	}
 	b.Emit(WSTrade, symbol, data)
 }
 
 func (b *ByBitWS) processKLine(symbol string, data KLine) {
+	for _, h := range b.handlers.get(WSKLine) {
+		h.fn.(func(string, KLine))(symbol, data)
+	}
 	b.Emit(WSKLine, symbol, data)
 }
 
 func (b *ByBitWS) processKLineV2(symbol string, data []*KLineV2) {
+	for _, h := range b.handlers.get(WSKLineV2) {
+		h.fn.(func(string, []*KLineV2))(symbol, data)
+	}
 	b.Emit(WSKLineV2, symbol, data)
 }
 
 func (b *ByBitWS) processCandle(symbol string, data []*KLineV2) {
+	for _, h := range b.handlers.get(WSCandle) {
+		h.fn.(func(string, []*KLineV2))(symbol, data)
+	}
 	b.Emit(WSCandle, symbol, data)
 }
 
 func (b *ByBitWS) processInsurance(currency string, data ...*Insurance) {
+	for _, h := range b.handlers.get(WSInsurance) {
+		h.fn.(func(string, []*Insurance))(currency, data)
+	}
 	b.Emit(WSInsurance, currency, data)
 }
 
 func (b *ByBitWS) processInstrument(symbol string, data ...*Instrument) {
+	for _, h := range b.handlers.get(WSInstrument) {
+		h.fn.(func(string, []*Instrument))(symbol, data)
+	}
 	b.Emit(WSInstrument, symbol, data)
 }
 
 func (b *ByBitWS) processLiquidation(symbol string, data *Liquidation) {
+	for _, h := range b.handlers.get(WSLiquidation) {
+		h.fn.(func(string, *Liquidation))(symbol, data)
+	}
 	b.Emit(WSLiquidation, symbol, data)
 }
 
 func (b *ByBitWS) processPosition(data ...*Position) {
+	for _, h := range b.handlers.get(WSPosition) {
+		h.fn.(func([]*Position))(data)
+	}
 	b.Emit(WSPosition, data)
 }
 
 func (b *ByBitWS) processExecution(data ...*Execution) {
+	for _, h := range b.handlers.get(WSExecution) {
+		h.fn.(func([]*Execution))(data)
+	}
 	b.Emit(WSExecution, data)
 }
 
 func (b *ByBitWS) processOrder(data ...*Order) {
+	for _, h := range b.handlers.get(WSOrder) {
+		h.fn.(func([]*Order))(data)
+	}
 	b.Emit(WSOrder, data)
 }
 
 func (b *ByBitWS) processStopOrder(data ...*StopOrder) {
+	for _, h := range b.handlers.get(WSStopOrder) {
+		h.fn.(func([]*StopOrder))(data)
+	}
 	b.Emit(WSStopOrder, data)
 }
 
 func (b *ByBitWS) processWallet(data ...*Wallet) {
+	for _, h := range b.handlers.get(WSWallet) {
+		h.fn.(func([]*Wallet))(data)
+	}
 	b.Emit(WSWallet, data)
 }
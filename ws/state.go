@@ -0,0 +1,48 @@
+package ws
+
+import (
+	"fmt"
+	"log"
+)
+
+// ConnectionEvent is a change of the connection state, emitted as WSDialing,
+// WSConnected, WSAuthenticated, WSDisconnected, WSReconnecting or WSGaveUp
+type ConnectionEvent struct {
+	Event   string // the event name, e.g. WSDisconnected
+	Attempt int    // dial attempt since the last connection, for WSDialing, WSReconnecting and WSGaveUp
+	Err     error  // why the connection was dropped or given up
+}
+
+func (b *ByBitWS) emitState(event ConnectionEvent) {
+	if b.cfg.DebugMode {
+		log.Printf("BybitWs %v attempt=%v err=%v", event.Event, event.Attempt, event.Err)
+	}
+	for _, h := range b.handlers.get(connectionEvents) {
+		h.fn.(func(ConnectionEvent))(event)
+	}
+	b.Emit(event.Event, event)
+}
+
+// dialHandler tells the first dial attempts from the reconnection attempts
+func (b *ByBitWS) dialHandler(attempt int) {
+	b.mu.RLock()
+	reconnecting := b.connected
+	b.mu.RUnlock()
+
+	if reconnecting {
+		b.emitState(ConnectionEvent{Event: WSReconnecting, Attempt: attempt})
+	} else {
+		b.emitState(ConnectionEvent{Event: WSDialing, Attempt: attempt})
+	}
+}
+
+func (b *ByBitWS) disconnectHandler(err error) {
+	b.failPending()
+	b.emitState(ConnectionEvent{Event: WSDisconnected, Err: err})
+}
+
+// giveUpHandler ends the connection for good once reconnecting is given up
+func (b *ByBitWS) giveUpHandler(err error) {
+	b.emitState(ConnectionEvent{Event: WSGaveUp, Attempt: b.conn.MaxAttempts, Err: err})
+	b.stop(fmt.Errorf("BybitWs gave up connecting after %v attempts: %w", b.conn.MaxAttempts, err))
+}
@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func nextState(t *testing.T, states chan ConnectionEvent) ConnectionEvent {
+	select {
+	case event := <-states:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("no connection event")
+		return ConnectionEvent{}
+	}
+}
+
+func recordStates(b *ByBitWS) chan ConnectionEvent {
+	states := make(chan ConnectionEvent, 100)
+	for _, event := range []string{WSDialing, WSConnected, WSAuthenticated, WSDisconnected, WSReconnecting, WSGaveUp} {
+		b.On(event, func(event ConnectionEvent) {
+			states <- event
+		})
+	}
+	return states
+}
+
+func TestStateEvents(t *testing.T) {
+	addr, _, conns := newTestServer(t)
+	b := newTestClient(addr, true)
+	states := recordStates(b)
+	b.Start()
+	defer b.Close()
+	server := <-conns
+
+	if event := nextState(t, states); event.Event != WSDialing || event.Attempt != 1 {
+		t.Errorf("unexpected %+v", event)
+	}
+	if event := nextState(t, states); event.Event != WSConnected {
+		t.Errorf("unexpected %+v", event)
+	}
+
+	server.Close()
+	<-conns
+	if event := nextState(t, states); event.Event != WSDisconnected || event.Err == nil {
+		t.Errorf("unexpected %+v", event)
+	}
+	if event := nextState(t, states); event.Event != WSReconnecting || event.Attempt != 1 {
+		t.Errorf("unexpected %+v", event)
+	}
+	if event := nextState(t, states); event.Event != WSConnected {
+		t.Errorf("unexpected %+v", event)
+	}
+}
+
+func TestStateAuthenticated(t *testing.T) {
+	b := startTestClient(t, &Configuration{ApiKey: "key", SecretKey: "secret"}, func(cmd string) string {
+		return `{"success":true,"ret_msg":"","request":{"op":"auth","args":["key"]}}`
+	})
+	states := recordStates(b)
+
+	if err := b.AuthAndWait(); err != nil {
+		t.Fatal(err)
+	}
+	if event := nextState(t, states); event.Event != WSAuthenticated {
+		t.Errorf("unexpected %+v", event)
+	}
+}
+
+func TestStateGaveUp(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := "ws://" + l.Addr().String()
+	l.Close()
+
+	b := newTestClient(addr, true)
+	b.conn.MaxAttempts = 3
+	states := recordStates(b)
+	b.Start()
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if event := nextState(t, states); event.Event != WSDialing || event.Attempt != attempt {
+			t.Errorf("unexpected %+v", event)
+		}
+	}
+	if event := nextState(t, states); event.Event != WSGaveUp || event.Err == nil {
+		t.Errorf("unexpected %+v", event)
+	}
+	waitDone(t, b)
+	if b.Err() == nil {
+		t.Error("no terminal error")
+	}
+}
@@ -0,0 +1,93 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestServer starts a websocket server sending every message it receives to
+// msgs, conns receives the server side of every connection
+func newTestServer(t *testing.T) (addr string, msgs chan string, conns chan *websocket.Conn) {
+	msgs = make(chan string, 100)
+	conns = make(chan *websocket.Conn, 10)
+	upgrader := websocket.Upgrader{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- c
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			msgs <- string(data)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return "ws" + strings.TrimPrefix(ts.URL, "http"), msgs, conns
+}
+
+// nextCmd returns the next message received by the server which is not a ping
+func nextCmd(t *testing.T, msgs chan string) string {
+	for {
+		select {
+		case msg := <-msgs:
+			if msg != `{"op":"ping"}` {
+				return msg
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("no message received")
+			return ""
+		}
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	b := New(&Configuration{})
+	b.Subscribe("trade.BTCUSD")
+	b.Subscribe("instrument_info.100ms.BTCUSD")
+	b.Subscribe("trade.BTCUSD")
+	b.Subscribe("trade.ETHUSD")
+	b.Unsubscribe("instrument_info.100ms.BTCUSD")
+	b.Unsubscribe("trade.XRPUSD")
+
+	got := b.Subscriptions()
+	want := []string{"trade.BTCUSD", "trade.ETHUSD"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Subscriptions() = %v, want %v", got, want)
+	}
+}
+
+func TestSubscriptionsReplayedOnReconnect(t *testing.T) {
+	addr, msgs, conns := newTestServer(t)
+	b := New(&Configuration{Addr: addr})
+	b.conn.HandshakeTimeout = 100 * time.Millisecond
+	b.conn.RecIntvlMin = 10 * time.Millisecond
+	b.Subscribe("trade.BTCUSD")
+	b.Subscribe("trade.ETHUSD")
+	b.Start()
+	<-conns
+
+	if msg := nextCmd(t, msgs); msg != `{"op":"subscribe","args":["trade.BTCUSD"]}` {
+		t.Errorf("unexpected %v", msg)
+	}
+	nextCmd(t, msgs)
+
+	b.Unsubscribe("trade.BTCUSD")
+	if msg := nextCmd(t, msgs); msg != `{"op":"unsubscribe","args":["trade.BTCUSD"]}` {
+		t.Errorf("unexpected %v", msg)
+	}
+
+	// the subscriptions sent once reconnected
+	b.subscribeHandler()
+	if msg := nextCmd(t, msgs); msg != `{"op":"subscribe","args":["trade.ETHUSD"]}` {
+		t.Errorf("only the active topics are replayed, got %v", msg)
+	}
+}
@@ -2,9 +2,6 @@ package ws
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -18,6 +15,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/tidwall/gjson"
 	"github.com/wilcosheh/bybit-api/recws"
+	"github.com/wilcosheh/bybit-api/signer"
 )
 
 const (
@@ -57,7 +55,15 @@ const (
 	WSStopOrder = "stop_order" // 条件单的更新: stop_order
 	WSWallet    = "wallet"     // 条件单的更新: stop_order
 
-	WSDisconnected = "disconnected" // WS断开事件
+	WSDisconnected = "disconnected" // WS断开事件: ConnectionEvent
+	WSAck          = "ack"          // 命令的确认: *Ack
+	WSError        = "error"        // 命令被拒绝或确认超时: *CmdError
+
+	WSDialing       = "dialing"       // 首次连接尝试: ConnectionEvent
+	WSConnected     = "connected"     // 连接建立, 重新订阅之前: ConnectionEvent
+	WSAuthenticated = "authenticated" // 私有频道鉴权成功: ConnectionEvent
+	WSReconnecting  = "reconnecting"  // 重连尝试: ConnectionEvent
+	WSGaveUp        = "gave_up"       // 放弃重连: ConnectionEvent
 )
 
 var (
@@ -65,12 +71,16 @@ var (
 )
 
 type Configuration struct {
-	Addr          string `json:"addr"`
-	Proxy         string `json:"proxy"` // http://127.0.0.1:1081
-	ApiKey        string `json:"api_key"`
-	SecretKey     string `json:"secret_key"`
-	AutoReconnect bool   `json:"auto_reconnect"`
-	DebugMode     bool   `json:"debug_mode"`
+	Addr          string        `json:"addr"`
+	Proxy         string        `json:"proxy"` // http://127.0.0.1:1081
+	ApiKey        string        `json:"api_key"`
+	SecretKey     string        `json:"secret_key"`
+	Signer        signer.Signer `json:"-"` // signs auth instead of SecretKey, e.g. signer.RSA
+	AutoReconnect bool          `json:"auto_reconnect"`
+	DebugMode     bool          `json:"debug_mode"`
+
+	AckTimeout           time.Duration `json:"ack_timeout"`            // wait for command acknowledgements, default to 5 seconds
+	MaxReconnectAttempts int           `json:"max_reconnect_attempts"` // failed dial attempts in a row before giving up, 0 never gives up
 }
 
 type ByBitWS struct {
@@ -81,10 +91,19 @@ type ByBitWS struct {
 	mu     sync.RWMutex
 	Ended  bool
 
-	subscribeCmds   []Cmd
+	started   bool
+	connected bool // connected at least once
+	stopOnce  sync.Once
+	err       error          // terminal error, see Err
+	wg        sync.WaitGroup // ping and read loops
+	done      chan struct{}
+
+	subscriptions   []string                   // active topics in subscription order
 	orderBookLocals map[string]*OrderBookLocal // key: symbol
 
-	emitter *emission.Emitter
+	acks     acks              // commands waiting for their acknowledgement
+	handlers handlers          // typed handlers, see OnTrade
+	emitter  *emission.Emitter // listeners added by On
 }
 
 func New(config *Configuration) *ByBitWS {
@@ -92,6 +111,7 @@ func New(config *Configuration) *ByBitWS {
 		cfg:             config,
 		emitter:         emission.NewEmitter(),
 		orderBookLocals: make(map[string]*OrderBookLocal),
+		done:            make(chan struct{}),
 	}
 	b.ctx, b.cancel = context.WithCancel(context.Background())
 
@@ -106,7 +126,11 @@ func New(config *Configuration) *ByBitWS {
 		}
 		b.conn.Proxy = http.ProxyURL(proxy)
 	}
+	b.conn.MaxAttempts = config.MaxReconnectAttempts
 	b.conn.SubscribeHandler = b.subscribeHandler
+	b.conn.DialHandler = b.dialHandler
+	b.conn.DisconnectHandler = b.disconnectHandler
+	b.conn.GiveUpHandler = b.giveUpHandler
 	return b
 }
 
@@ -116,17 +140,19 @@ func (b *ByBitWS) subscribeHandler() error {
 	}
 
 	b.mu.Lock()
-	defer b.mu.Unlock()
+	b.connected = true
+	b.mu.Unlock()
+	b.emitState(ConnectionEvent{Event: WSConnected})
 
-	if b.cfg.ApiKey != "" && b.cfg.SecretKey != "" {
+	if b.cfg.ApiKey != "" && b.signer() != nil {
 		err := b.Auth()
 		if err != nil {
 			log.Printf("BybitWs auth error: %v", err)
 		}
 	}
 
-	for _, cmd := range b.subscribeCmds {
-		err := b.SendCmd(cmd)
+	for _, topic := range b.Subscriptions() {
+		_, err := b.sendTracked(Cmd{Op: "subscribe", Args: []interface{}{topic}})
 		if err != nil {
 			log.Printf("BybitWs SendCmd return error: %v", err)
 		}
@@ -147,13 +173,67 @@ func (b *ByBitWS) IsConnected() bool {
 	return b.conn.IsConnected()
 }
 
-func (b *ByBitWS) Subscribe(arg string) {
-	cmd := Cmd{
-		Op:   "subscribe",
-		Args: []interface{}{arg},
+// Subscribe subscribes to topic, e.g. "trade.BTCUSD", now if connected and on
+// every reconnect. Subscribing to an active topic again does nothing.
+// A rejection or a missing acknowledgement is emitted as WSError.
+func (b *ByBitWS) Subscribe(topic string) {
+	if b.addSubscription(topic) {
+		b.sendTracked(Cmd{Op: "subscribe", Args: []interface{}{topic}})
+	}
+}
+
+// SubscribeAndWait subscribes to topic and waits for the server acknowledgement,
+// it returns a *CmdError when the topic is rejected or the acknowledgement times out.
+// A rejected topic is not resubscribed on reconnect, while a topic that could not
+// be sent for lack of connection is subscribed once connected.
+func (b *ByBitWS) SubscribeAndWait(topic string) error {
+	if !b.addSubscription(topic) {
+		return nil
 	}
-	b.subscribeCmds = append(b.subscribeCmds, cmd)
-	b.SendCmd(cmd)
+	return b.sendAndWait(Cmd{Op: "subscribe", Args: []interface{}{topic}})
+}
+
+// Unsubscribe unsubscribes from topic, now if connected and for the reconnects to come
+func (b *ByBitWS) Unsubscribe(topic string) {
+	if b.removeSubscription(topic) {
+		b.sendTracked(Cmd{Op: "unsubscribe", Args: []interface{}{topic}})
+	}
+}
+
+// addSubscription adds topic to the subscriptions, false if already there
+func (b *ByBitWS) addSubscription(topic string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, t := range b.subscriptions {
+		if t == topic {
+			return false
+		}
+	}
+	b.subscriptions = append(b.subscriptions, topic)
+	return true
+}
+
+// removeSubscription removes topic from the subscriptions, false if not there
+func (b *ByBitWS) removeSubscription(topic string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, t := range b.subscriptions {
+		if t == topic {
+			b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Subscriptions returns the active topics in subscription order
+func (b *ByBitWS) Subscriptions() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]string(nil), b.subscriptions...)
 }
 
 func (b *ByBitWS) SendCmd(cmd Cmd) error {
@@ -175,43 +255,151 @@ func (b *ByBitWS) Send(msg string) (err error) {
 	return
 }
 
+// Start connects and runs the ping and read loops in the background until Close.
+// With AutoReconnect the read loop survives reconnections, otherwise the first
+// read error ends the connection for good, see Done and Err.
 func (b *ByBitWS) Start() error {
-	b.connect()
+	b.mu.Lock()
+	if b.started || b.ctx.Err() != nil {
+		b.mu.Unlock()
+		return errors.New("BybitWs already started or closed")
+	}
+	b.started = true
+	b.mu.Unlock()
 
-	cancel := make(chan struct{})
+	b.connect()
+	if b.ctx.Err() != nil {
+		// closed while connecting
+		b.conn.Close()
+	}
 
+	b.wg.Add(2)
+	go b.pingLoop()
+	go b.readLoop()
 	go func() {
-		t := time.NewTicker(time.Second * 5)
-		defer t.Stop()
-		for {
-			select {
-			case <-t.C:
-				b.ping()
-			case <-cancel:
-				return
-			}
-		}
+		b.wg.Wait()
+		b.conn.Wait()
+		close(b.done)
 	}()
+	return nil
+}
 
-	go func() {
-		defer close(cancel)
+// Run starts the connection and blocks until ctx is done, then closes it,
+// or until the connection ends for good. It returns Err.
+func (b *ByBitWS) Run(ctx context.Context) error {
+	if err := b.Start(); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		b.stop(ctx.Err())
+		<-b.done
+	case <-b.done:
+	}
+	return b.Err()
+}
 
-		for {
-			messageType, data, err := b.conn.ReadMessage()
-			if err != nil {
+// Close closes the connection and waits for the ping, read and reconnection loops to exit
+func (b *ByBitWS) Close() error {
+	b.stop(nil)
+
+	b.mu.RLock()
+	started := b.started
+	b.mu.RUnlock()
+	if started {
+		<-b.done
+	}
+	return nil
+}
+
+// Done is closed once the connection ended for good and its loops exited
+func (b *ByBitWS) Done() <-chan struct{} {
+	return b.done
+}
+
+// Err returns why the connection ended: nil when closed by Close, the context
+// error when Run's context is done, or the read error without AutoReconnect
+func (b *ByBitWS) Err() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.err
+}
+
+// stop ends the connection for good with err, the first call wins
+func (b *ByBitWS) stop(err error) {
+	b.stopOnce.Do(func() {
+		b.mu.Lock()
+		b.err = err
+		b.Ended = true
+		started := b.started
+		b.started = true // a stopped connection cannot be started
+		b.mu.Unlock()
+
+		b.cancel()
+		b.conn.Close()
+		if !started {
+			close(b.done)
+		}
+	})
+}
+
+func (b *ByBitWS) pingLoop() {
+	defer b.wg.Done()
+
+	t := time.NewTicker(time.Second * 5)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			b.ping()
+		case <-b.ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *ByBitWS) readLoop() {
+	defer b.wg.Done()
+
+	for {
+		messageType, data, err := b.conn.ReadMessage()
+		if err != nil {
+			if b.ctx.Err() != nil {
+				return
+			}
+			if !b.cfg.AutoReconnect {
 				log.Printf("BybitWs Read error, closing connection: %v", err)
-				b.conn.Close()
-				b.Ended = true
+				b.stop(err)
 				return
 			}
-
-			if err = b.processMessage(messageType, data); err != nil {
-				log.Printf("BybitWs process error: %v", err)
+			if b.cfg.DebugMode {
+				log.Printf("BybitWs Read error, waiting for reconnection: %v", err)
+			}
+			if !b.waitConnected() {
+				return
 			}
+			continue
 		}
-	}()
 
-	return nil
+		if err = b.processMessage(messageType, data); err != nil {
+			log.Printf("BybitWs process error: %v", err)
+		}
+	}
+}
+
+// waitConnected waits for the connection to be reestablished, false once closed
+func (b *ByBitWS) waitConnected() bool {
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for !b.IsConnected() {
+		select {
+		case <-b.ctx.Done():
+			return false
+		case <-t.C:
+		}
+	}
+	return true
 }
 
 func (b *ByBitWS) connect() {
@@ -234,15 +422,52 @@ func (b *ByBitWS) ping() {
 	}
 }
 
+// signer returns the configured Signer, an HMAC signer over SecretKey or nil
+func (b *ByBitWS) signer() signer.Signer {
+	if b.cfg.Signer != nil {
+		return b.cfg.Signer
+	}
+	if b.cfg.SecretKey != "" {
+		return signer.NewHMAC(b.cfg.SecretKey)
+	}
+	return nil
+}
+
+// Auth authenticates the connection for the private topics, a rejection or a
+// missing acknowledgement is emitted as WSError
 func (b *ByBitWS) Auth() error {
+	cmd, err := b.authCmd()
+	if err != nil {
+		return err
+	}
+	_, err = b.sendTracked(cmd)
+	return err
+}
+
+// AuthAndWait authenticates the connection and waits for the server acknowledgement,
+// it returns a *CmdError when authentication fails or times out
+func (b *ByBitWS) AuthAndWait() error {
+	cmd, err := b.authCmd()
+	if err != nil {
+		return err
+	}
+	return b.sendAndWait(cmd)
+}
+
+func (b *ByBitWS) authCmd() (cmd Cmd, err error) {
+	s := b.signer()
+	if s == nil {
+		return cmd, errors.New("BybitWs auth: no secret key or signer configured")
+	}
 	// 单位:毫秒
 	expires := time.Now().Unix()*1000 + 10000
 	req := fmt.Sprintf("GET/realtime%d", expires)
-	sig := hmac.New(sha256.New, []byte(b.cfg.SecretKey))
-	sig.Write([]byte(req))
-	signature := hex.EncodeToString(sig.Sum(nil))
+	signature, err := s.Sign([]byte(req))
+	if err != nil {
+		return
+	}
 
-	cmd := Cmd{
+	cmd = Cmd{
 		Op: "auth",
 		Args: []interface{}{
 			b.cfg.ApiKey,
@@ -251,8 +476,7 @@ func (b *ByBitWS) Auth() error {
 			signature,
 		},
 	}
-	err := b.SendCmd(cmd)
-	return err
+	return
 }
 
 func (b *ByBitWS) processMessage(messageType int, data []byte) error {
@@ -265,6 +489,13 @@ func (b *ByBitWS) processMessage(messageType int, data []byte) error {
 	// 处理心跳包
 	if ret.Get("ret_msg").String() == "pong" {
 		b.handlePong()
+	} else if ret.Get("request").Exists() {
+		var ack Ack
+		if err := json.Unmarshal(data, &ack); err != nil {
+			return err
+		}
+		b.processAck(&ack)
+		return nil
 	}
 
 	if topicValue := ret.Get("topic"); topicValue.Exists() {
@@ -144,4 +144,54 @@ func TestParseOrderEvent(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	if data[0].Price.String() != "6855" || data[0].CumExecFee.String() != "0.00000011" {
+		t.Errorf("unexpected price %v fee %v", data[0].Price, data[0].CumExecFee)
+	}
+}
+
+func TestParseStopOrderEvent(t *testing.T) {
+	s := `{"topic":"stop_order","data":[{"order_id":"3f4e0e8a-7a7e-4d1a-9a4c-02c1f6e1c3a2","order_link_id":"sl-1","user_id":1,"symbol":"BTCUSDT","side":"Buy","order_type":"Market",
+"price":0,"qty":0.01,"time_in_force":"ImmediateOrCancel","create_type":"CreateByStopOrder","cancel_type":"","order_status":"Untriggered","stop_order_type":"Stop","trigger_by":"LastPrice","trigger_price":36000,"take_profit":38000,"stop_loss":0,"tp_trigger_by":"MarkPrice","sl_trigger_by":"LastPrice","reduce_only":true,"close_on_trigger":false,"timestamp":"2022-01-25T02:06:25Z"
+}]}`
+	ret := gjson.Parse(s)
+	raw := ret.Get("data").Raw
+	var data []*StopOrder
+	err := json.Unmarshal([]byte(raw), &data)
+	if err != nil {
+		t.Error(err)
+	}
+	if data[0].TriggerPrice.String() != "36000" || data[0].TakeProfit.String() != "38000" || !data[0].ReduceOnly {
+		t.Errorf("unexpected trigger price %v take profit %v", data[0].TriggerPrice, data[0].TakeProfit)
+	}
+}
+
+func TestParseDecimalEvents(t *testing.T) {
+	b := New(&Configuration{})
+	var book OrderBook
+	b.OnOrderBook(func(symbol string, ob OrderBook) {
+		book = ob
+	})
+	var klines []*KLineV2
+	b.OnCandle(func(symbol string, data []*KLineV2) {
+		klines = data
+	})
+
+	err := b.processMessage(1, []byte(`{"topic":"orderBookL2_25.BTCUSD","type":"snapshot","data":{"order_book":[
+{"price":"35000.5","symbol":"BTCUSD","id":"350005000","side":"Buy","size":10},
+{"price":"35001.0","symbol":"BTCUSD","id":"350010000","side":"Sell","size":5},
+{"price":"35000.0","symbol":"BTCUSD","id":"350000000","side":"Buy","size":7}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Bids) != 2 || book.Bids[0].Price.String() != "35000.5" || book.Bids[1].Amount.String() != "7" || book.Asks[0].Price.String() != "35001" {
+		t.Errorf("unexpected order book %+v", book)
+	}
+
+	err = b.processMessage(1, []byte(`{"topic":"candle.1.BTCUSDT","data":[{"start":1642899600,"end":1642899660,"open":36000.5,"close":36001,"high":36002,"low":35999.5,"volume":"0.123","turnover":"4428.0615","confirm":false,"cross_seq":1,"timestamp":1642899630}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 1 || klines[0].Open.String() != "36000.5" || klines[0].Volume.String() != "0.123" {
+		t.Errorf("unexpected candles %+v", klines)
+	}
 }